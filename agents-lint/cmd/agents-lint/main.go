@@ -6,9 +6,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/michaellady/agents-lint/internal/baseline"
 	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/config"
 	"github.com/michaellady/agents-lint/internal/report"
 	"github.com/michaellady/agents-lint/internal/rules"
 	"github.com/michaellady/agents-lint/internal/transcript"
@@ -32,6 +37,8 @@ func main() {
 	switch os.Args[1] {
 	case "check":
 		os.Exit(runCheck(os.Args[2:]))
+	case "baseline":
+		os.Exit(runBaseline(os.Args[2:]))
 	case "validate":
 		os.Exit(runValidate(os.Args[2:]))
 	case "list":
@@ -49,21 +56,36 @@ func printUsage() {
 	fmt.Fprintln(os.Stderr, `agents-lint - Validate Claude Code transcripts against AGENTS.md rules
 
 Usage:
-  agents-lint check [options] <transcript.ndjson>
+  agents-lint check [options] <transcript|dir|glob|->...
+  agents-lint baseline [options] <transcript|dir|glob|->...
   agents-lint validate [options] <AGENTS.md>
   agents-lint list [--format=json]
   agents-lint <transcript.ndjson>  (shorthand for check)
 
 Commands:
-  check      Run checkers on a transcript file
+  check      Run checkers on transcripts (directories are searched for
+             *.ndjson and *.jsonl files; - reads standard input)
+  baseline   Record current violations so check only fails on new ones
   validate   Validate AGENTS.md file structure
   list       List all available checkers
 
 Check Options:
   -checker string   Run only specific checker(s), comma-separated
-  -format string    Output format: text (default) or json
+  -config string    Config file (default: .agents-lint.yaml in the current
+                    directory or a parent)
+  -ignore-file string
+                    Suppression file (default: .agents-lint-ignore in the
+                    transcript's directory or a parent)
+  -format string    Output format: text (default), json, sarif, junit, or
+                    github (workflow annotations and $GITHUB_STEP_SUMMARY)
   -fail-on string   Fail on: error (default), warning, or info
   -verbose          Show detailed output (text format only)
+  -baseline string  Baseline file; only violations not in it fail
+  -j int            Transcripts to check concurrently (default: number of CPUs)
+
+Baseline Options:
+  -o string         File to write (default .agents-lint-baseline.json, - for stdout)
+  -checker, -config, -ignore-file as for check
 
 Validate Options:
   -format string    Output format: text (default) or json
@@ -71,15 +93,21 @@ Validate Options:
 Exit Codes:
   0  All checks passed
   1  One or more violations found (at specified severity)
-  2  Error (invalid args, file not found, parse error)`)
+  2  Error (invalid args, file not found, parse error)
+
+With several transcripts, the exit code is that of the worst one.`)
 }
 
 func runCheck(args []string) int {
 	fs := flag.NewFlagSet("check", flag.ExitOnError)
 	checkerFlag := fs.String("checker", "", "Run only specific checker(s), comma-separated")
-	format := fs.String("format", "text", "Output format: text or json")
+	configPath := fs.String("config", "", "Config file (default: .agents-lint.yaml in the current directory or a parent)")
+	ignorePath := fs.String("ignore-file", "", "Suppression file (default: .agents-lint-ignore in the transcript's directory or a parent)")
+	format := fs.String("format", "text", "Output format: text, json, sarif, junit, or github")
 	failOn := fs.String("fail-on", "error", "Fail on: error, warning, or info")
 	verbose := fs.Bool("verbose", false, "Show detailed output (text format only)")
+	baselinePath := fs.String("baseline", "", "Baseline file; only violations not in it fail")
+	jobs := fs.Int("j", runtime.NumCPU(), "Number of transcripts to check concurrently")
 
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -91,59 +119,340 @@ func runCheck(args []string) int {
 		return exitError
 	}
 
-	path := fs.Arg(0)
-	t, err := transcript.ParseFile(path)
+	threshold, err := checker.ParseSeverity(*failOn)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing transcript: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Unknown fail-on value: %s\n", *failOn)
 		return exitError
 	}
 
-	var result *checker.Result
-	if *checkerFlag != "" {
-		ids := strings.Split(*checkerFlag, ",")
-		result = checker.RunByIDs(t, ids)
-	} else {
-		result = checker.RunAll(t)
+	switch *format {
+	case "text", "json", "sarif", "junit", "github":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", *format)
+		return exitError
+	}
+
+	checkers, err := selectCheckers(*checkerFlag, *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	var known *baseline.Baseline
+	if *baselinePath != "" {
+		known, err = baseline.Load(*baselinePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitError
+		}
+	}
+
+	paths, err := transcript.FindFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	// Check files concurrently, keeping results in argument order
+	checked := make([]*checker.Result, len(paths))
+	errs := make([]error, len(paths))
+	forEach(len(paths), *jobs, func(i int) {
+		checked[i], errs[i] = checkFile(paths[i], checkers, *ignorePath, known)
+	})
+
+	code := exitOK
+	var results []*checker.Result
+	for i, result := range checked {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "Error checking %s: %v\n", displayPath(paths[i]), errs[i])
+			code = exitError
+			continue
+		}
+		results = append(results, result)
+		if code == exitOK && failed(result, threshold) {
+			code = exitViolations
+		}
+	}
+	if len(results) == 0 {
+		return exitError
 	}
-	result.TranscriptPath = path
 
 	// Output results
 	switch *format {
 	case "json":
-		if err := report.WriteJSON(os.Stdout, result); err != nil {
+		if len(paths) == 1 {
+			err = report.WriteJSON(os.Stdout, results[0])
+		} else {
+			err = report.WriteJSONAggregate(os.Stdout, results)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing JSON: %v\n", err)
 			return exitError
 		}
+	case "sarif":
+		if err := report.WriteSARIF(os.Stdout, results...); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing SARIF: %v\n", err)
+			return exitError
+		}
+	case "junit":
+		if err := report.WriteJUnit(os.Stdout, results...); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing JUnit: %v\n", err)
+			return exitError
+		}
+	case "github":
+		for _, result := range results {
+			result.TranscriptPath = workspacePath(result.TranscriptPath)
+			report.WriteGitHub(os.Stdout, result)
+			if err := writeStepSummary(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing job summary: %v\n", err)
+				return exitError
+			}
+		}
 	case "text":
-		report.WriteText(os.Stdout, result, *verbose)
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", *format)
+		for _, result := range results {
+			report.WriteText(os.Stdout, result, *verbose)
+		}
+		if len(paths) > 1 {
+			report.WriteTextTotals(os.Stdout, results)
+		}
+	}
+
+	return code
+}
+
+// checkFile parses and checks a single transcript, or standard input if
+// path is transcript.Stdin.
+func checkFile(path string, checkers []checker.Checker, ignorePath string, known *baseline.Baseline) (*checker.Result, error) {
+	dir := filepath.Dir(path)
+	if path == transcript.Stdin {
+		dir = "."
+	}
+	suppressions, err := loadIgnore(ignorePath, dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading ignore file: %w", err)
+	}
+
+	t, err := parseTranscript(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transcript: %w", err)
+	}
+
+	result := checker.Run(t, checkers, suppressions...)
+	result.TranscriptPath = displayPath(path)
+	if known != nil {
+		known.Apply(t, result)
+	}
+	return result, nil
+}
+
+// parseTranscript parses the transcript at path, or standard input if path
+// is transcript.Stdin.
+func parseTranscript(path string) (*transcript.Transcript, error) {
+	if path == transcript.Stdin {
+		return transcript.Parse(os.Stdin)
+	}
+	return transcript.ParseFile(path)
+}
+
+// displayPath names a transcript path in output.
+func displayPath(path string) string {
+	if path == transcript.Stdin {
+		return "<stdin>"
+	}
+	return path
+}
+
+// failed reports whether a result has violations at or above threshold.
+func failed(result *checker.Result, threshold checker.Severity) bool {
+	for _, v := range result.Violations {
+		if v.Severity >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// forEach calls fn for 0..n-1 on up to workers goroutines and waits for
+// all calls to return.
+func forEach(n, workers int, fn func(i int)) {
+	workers = max(1, min(workers, n))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+func runBaseline(args []string) int {
+	fs := flag.NewFlagSet("baseline", flag.ExitOnError)
+	checkerFlag := fs.String("checker", "", "Run only specific checker(s), comma-separated")
+	configPath := fs.String("config", "", "Config file (default: .agents-lint.yaml in the current directory or a parent)")
+	ignorePath := fs.String("ignore-file", "", "Suppression file (default: .agents-lint-ignore in the transcript's directory or a parent)")
+	output := fs.String("o", baseline.DefaultFile, "Baseline file to write (- for stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Error: transcript file path required")
+		return exitError
+	}
+
+	checkers, err := selectCheckers(*checkerFlag, *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return exitError
 	}
 
-	// Determine exit code based on --fail-on
-	errors, warnings, infos := result.Summary()
-	switch *failOn {
-	case "error":
-		if errors > 0 {
-			return exitViolations
+	paths, err := transcript.FindFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	b := baseline.New()
+	violations := 0
+	for _, path := range paths {
+		suppressions, err := loadIgnore(*ignorePath, filepath.Dir(path))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading ignore file: %v\n", err)
+			return exitError
 		}
-	case "warning":
-		if errors > 0 || warnings > 0 {
-			return exitViolations
+
+		t, err := parseTranscript(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing transcript %s: %v\n", displayPath(path), err)
+			return exitError
 		}
-	case "info":
-		if errors > 0 || warnings > 0 || infos > 0 {
-			return exitViolations
+
+		result := checker.Run(t, checkers, suppressions...)
+		b.Add(t, result)
+		violations += len(result.Violations)
+	}
+
+	w := os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing baseline: %v\n", err)
+			return exitError
 		}
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown fail-on value: %s\n", *failOn)
+		defer f.Close()
+		w = f
+	}
+	if err := b.Write(w); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing baseline: %v\n", err)
 		return exitError
 	}
 
+	if *output != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %d violations from %d transcripts to %s\n", violations, len(paths), *output)
+	}
 	return exitOK
 }
 
+// selectCheckers returns the checkers to run, configured from the config
+// file. Explicitly requested checkers run even if the config disables them.
+func selectCheckers(ids, configPath string) ([]checker.Checker, error) {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	var checkers []checker.Checker
+	if ids != "" {
+		for _, id := range strings.Split(ids, ",") {
+			if c := checker.GetByID(id); c != nil {
+				checkers = append(checkers, c)
+			}
+		}
+	} else {
+		for _, c := range checker.GetAll() {
+			if cfg.Enabled(c.ID()) {
+				checkers = append(checkers, c)
+			}
+		}
+	}
+
+	checkers, err = cfg.Apply(checkers)
+	if err != nil {
+		return nil, fmt.Errorf("applying config: %w", err)
+	}
+	return checkers, nil
+}
+
+// loadConfig loads the config file at path or, if path is empty, the one
+// found from the current directory. It returns nil if there is none.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		found, err := config.Find(".")
+		if err != nil || found == "" {
+			return nil, err
+		}
+		path = found
+	}
+	return config.Load(path)
+}
+
+// loadIgnore loads the suppression file at path or, if path is empty, the
+// one found from dir.
+func loadIgnore(path, dir string) ([]checker.Suppression, error) {
+	if path == "" {
+		found, err := config.FindIgnore(dir)
+		if err != nil || found == "" {
+			return nil, err
+		}
+		path = found
+	}
+	return config.LoadIgnore(path)
+}
+
+// workspacePath returns path relative to $GITHUB_WORKSPACE, the repository
+// root that annotation file paths are resolved against. Paths outside the
+// workspace are returned unchanged.
+func workspacePath(path string) string {
+	workspace := os.Getenv("GITHUB_WORKSPACE")
+	if workspace == "" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(workspace, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// writeStepSummary appends a Markdown summary to $GITHUB_STEP_SUMMARY, if set.
+func writeStepSummary(result *checker.Result) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	report.WriteGitHubSummary(f, result)
+	return f.Close()
+}
+
 func runList(args []string) int {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
 	format := fs.String("format", "text", "Output format: text or json")
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/michaellady/agents-lint/internal/report"
 	"github.com/michaellady/agents-lint/internal/runner"
 	"github.com/michaellady/agents-lint/internal/scenario"
 
@@ -60,6 +61,7 @@ Run Options:
   -output string    Directory to save transcripts (default ".")
   -model string     Override model (e.g., "sonnet", "haiku")
   -dry-run          Show commands without executing
+  -report string    Write a JUnit XML report to this file
   -verbose          Enable detailed output
 
 Exit Codes:
@@ -74,6 +76,7 @@ func runScenarios(args []string) int {
 	outputDir := fs.String("output", ".", "Directory to save transcripts")
 	model := fs.String("model", "", "Override model")
 	dryRun := fs.Bool("dry-run", false, "Show commands without executing")
+	reportPath := fs.String("report", "", "Write a JUnit XML report to this file")
 	verbose := fs.Bool("verbose", false, "Enable detailed output")
 
 	if err := fs.Parse(args); err != nil {
@@ -137,6 +140,7 @@ func runScenarios(args []string) int {
 
 	// Run scenarios
 	var passed, failed int
+	var results []*scenario.Result
 	for _, s := range scenarios {
 		fmt.Printf("Running: %s\n", s.Name)
 		if s.Description != "" && *verbose {
@@ -144,6 +148,7 @@ func runScenarios(args []string) int {
 		}
 
 		result := r.Run(ctx, s)
+		results = append(results, result)
 
 		if result.Error != nil {
 			fmt.Printf("  ERROR: %v\n", result.Error)
@@ -169,6 +174,13 @@ func runScenarios(args []string) int {
 		}
 	}
 
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			return exitError
+		}
+	}
+
 	if *dryRun {
 		return exitOK
 	}
@@ -182,6 +194,19 @@ func runScenarios(args []string) int {
 	return exitOK
 }
 
+// writeReport writes scenario results to path as JUnit XML.
+func writeReport(path string, results []*scenario.Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := report.WriteScenarioJUnit(f, results); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func listScenarios(args []string) int {
 	if len(args) < 1 {
 		fmt.Fprintln(os.Stderr, "Error: scenarios directory required")
@@ -3,3 +3,5 @@ module github.com/michaellady/agents-lint
 go 1.25.4
 
 require gopkg.in/yaml.v3 v3.0.1
+
+require mvdan.cc/sh/v3 v3.13.1
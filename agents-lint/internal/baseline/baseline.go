@@ -0,0 +1,185 @@
+// Package baseline records known violations so that checks only fail on
+// new ones.
+package baseline
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// DefaultFile is the file written by `agents-lint baseline` by default.
+const DefaultFile = ".agents-lint-baseline.json"
+
+// version is the current baseline file format.
+const version = 1
+
+// Baseline is a snapshot of known violations.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a fingerprinted violation. Fingerprints ignore event UUIDs and
+// tool_use IDs, which differ between runs, and numbers in messages, which
+// change as unrelated parts of a transcript do.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	SessionID   string `json:"session_id,omitempty"`
+	CheckerID   string `json:"checker_id"`
+	Rule        string `json:"rule"`
+	Message     string `json:"message"`
+	InputHash   string `json:"input_hash,omitempty"`
+
+	// Count is how many times the violation occurs.
+	Count int `json:"count"`
+}
+
+// New returns an empty baseline.
+func New() *Baseline {
+	return &Baseline{Version: version}
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	if b.Version != version {
+		return nil, fmt.Errorf("baseline %s: unsupported version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline as JSON, with entries in a stable order.
+func (b *Baseline) Write(w io.Writer) error {
+	slices.SortFunc(b.Entries, func(x, y Entry) int {
+		return cmp.Or(
+			cmp.Compare(x.SessionID, y.SessionID),
+			cmp.Compare(x.CheckerID, y.CheckerID),
+			cmp.Compare(x.Fingerprint, y.Fingerprint),
+		)
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Add records the violations of a checked transcript.
+func (b *Baseline) Add(t *transcript.Transcript, result *checker.Result) {
+	index := make(map[string]int, len(b.Entries))
+	for i, e := range b.Entries {
+		index[e.Fingerprint] = i
+	}
+
+	for _, v := range result.Violations {
+		e := Fingerprint(t, v)
+		if i, ok := index[e.Fingerprint]; ok {
+			b.Entries[i].Count++
+			continue
+		}
+		e.Count = 1
+		index[e.Fingerprint] = len(b.Entries)
+		b.Entries = append(b.Entries, e)
+	}
+}
+
+// Apply moves violations found in the baseline to result.Suppressed and
+// sets result.Fixed to the number of baseline violations, from checkers
+// that ran on this session, that no longer occur.
+func (b *Baseline) Apply(t *transcript.Transcript, result *checker.Result) {
+	remaining := make(map[string]int)
+	for _, e := range b.Entries {
+		if e.SessionID == t.SessionID && slices.Contains(result.CheckersRun, e.CheckerID) {
+			remaining[e.Fingerprint] += e.Count
+		}
+	}
+
+	var violations []checker.Violation
+	for _, v := range result.Violations {
+		fp := Fingerprint(t, v).Fingerprint
+		if remaining[fp] == 0 {
+			violations = append(violations, v)
+			continue
+		}
+		remaining[fp]--
+		result.Suppressed = append(result.Suppressed, checker.SuppressedViolation{
+			Violation:   v,
+			Suppression: checker.Suppression{Source: "baseline", Reason: "known violation"},
+		})
+	}
+	result.Violations = violations
+
+	result.Fixed = 0
+	for _, n := range remaining {
+		result.Fixed += n
+	}
+}
+
+// Fingerprint returns the baseline entry for a violation.
+func Fingerprint(t *transcript.Transcript, v checker.Violation) Entry {
+	e := Entry{
+		SessionID: t.SessionID,
+		CheckerID: v.CheckerID,
+		Rule:      v.Rule,
+		Message:   normalizeMessage(v.Message),
+		InputHash: inputHash(t, v.ToolCallID),
+	}
+
+	h := sha256.New()
+	for _, s := range []string{e.SessionID, e.CheckerID, e.Rule, e.Message, e.InputHash} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	e.Fingerprint = hex.EncodeToString(h.Sum(nil))[:16]
+	return e
+}
+
+var numberPattern = regexp.MustCompile(`\d+`)
+
+// normalizeMessage replaces numbers and collapses whitespace.
+func normalizeMessage(msg string) string {
+	msg = numberPattern.ReplaceAllString(msg, "N")
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// inputHash hashes the canonical JSON input of a tool call, or returns ""
+// if the violation isn't tied to one.
+func inputHash(t *transcript.Transcript, toolCallID string) string {
+	if toolCallID == "" {
+		return ""
+	}
+	for _, tc := range t.ToolCalls {
+		if tc.ID != toolCallID {
+			continue
+		}
+
+		// Re-encoding sorts object keys and drops insignificant whitespace
+		data := []byte(tc.Name)
+		var input any
+		if err := json.Unmarshal(tc.Input, &input); err == nil {
+			canonical, _ := json.Marshal(input)
+			data = append(data, canonical...)
+		}
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])[:16]
+	}
+	return ""
+}
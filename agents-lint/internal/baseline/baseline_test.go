@@ -0,0 +1,131 @@
+package baseline
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func bashTranscript(session string, commands ...string) *transcript.Transcript {
+	tr := &transcript.Transcript{SessionID: session}
+	for i, command := range commands {
+		input, _ := json.Marshal(map[string]string{"command": command})
+		tr.ToolCalls = append(tr.ToolCalls, transcript.ToolCall{
+			ID:    "toolu_" + string(rune('a'+i)),
+			Name:  "Bash",
+			Input: input,
+		})
+	}
+	return tr
+}
+
+func violation(toolCallID, message string) checker.Violation {
+	return checker.Violation{CheckerID: "git-branch", Rule: "Rule 3", Message: message, ToolCallID: toolCallID}
+}
+
+func TestFingerprint_Stable(t *testing.T) {
+	a := bashTranscript("s1", "git push origin main")
+	b := bashTranscript("s1", "ls", "git push origin main")
+
+	// Same command under a different tool_use ID, with a different count in the message
+	fa := Fingerprint(a, violation("toolu_a", "Pushed after 3 edits"))
+	fb := Fingerprint(b, violation("toolu_b", "Pushed after 12 edits"))
+	if fa.Fingerprint != fb.Fingerprint {
+		t.Errorf("fingerprints differ: %+v vs %+v", fa, fb)
+	}
+	if fa.Message != "Pushed after N edits" {
+		t.Errorf("Message = %q, want normalized", fa.Message)
+	}
+
+	// Different input or session changes the fingerprint
+	c := bashTranscript("s1", "git push upstream main")
+	if Fingerprint(c, violation("toolu_a", "Pushed after 3 edits")).Fingerprint == fa.Fingerprint {
+		t.Error("different tool input has same fingerprint")
+	}
+	d := bashTranscript("s2", "git push origin main")
+	if Fingerprint(d, violation("toolu_a", "Pushed after 3 edits")).Fingerprint == fa.Fingerprint {
+		t.Error("different session has same fingerprint")
+	}
+}
+
+func TestApply(t *testing.T) {
+	tr := bashTranscript("s1", "git push origin main", "git push origin main", "git push origin +main")
+
+	b := New()
+	b.Add(tr, &checker.Result{Violations: []checker.Violation{
+		violation("toolu_a", "Direct push"),
+		violation("toolu_b", "Direct push"),
+		{CheckerID: "context-report", Rule: "Rule 5", Message: "Missing report"},
+	}})
+	if len(b.Entries) != 2 || b.Entries[0].Count != 2 {
+		t.Fatalf("Entries = %+v, want direct push with count 2 and context report", b.Entries)
+	}
+
+	// One direct push fixed, the context report fixed, and a new force push
+	result := &checker.Result{
+		CheckersRun: []string{"git-branch", "context-report"},
+		Violations: []checker.Violation{
+			violation("toolu_a", "Direct push"),
+			violation("toolu_c", "Force push"),
+		},
+	}
+	b.Apply(tr, result)
+
+	if len(result.Violations) != 1 || result.Violations[0].ToolCallID != "toolu_c" {
+		t.Errorf("Violations = %+v, want only the new force push", result.Violations)
+	}
+	if len(result.Suppressed) != 1 || result.Suppressed[0].Suppression.Source != "baseline" {
+		t.Errorf("Suppressed = %+v, want the known direct push", result.Suppressed)
+	}
+	if result.Fixed != 2 {
+		t.Errorf("Fixed = %d, want 2", result.Fixed)
+	}
+}
+
+func TestApply_IgnoresOtherSessionsAndCheckers(t *testing.T) {
+	tr := bashTranscript("s1", "git push origin main")
+	b := New()
+	b.Add(tr, &checker.Result{Violations: []checker.Violation{violation("toolu_a", "Direct push")}})
+	b.Add(bashTranscript("s2"), &checker.Result{Violations: []checker.Violation{{CheckerID: "context-report", Message: "x"}}})
+
+	result := &checker.Result{CheckersRun: []string{"no-todowrite"}}
+	b.Apply(tr, result)
+	if result.Fixed != 0 {
+		t.Errorf("Fixed = %d, want 0 (git-branch didn't run, s2 is another session)", result.Fixed)
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	tr := bashTranscript("s1", "git push origin main")
+	b := New()
+	b.Add(tr, &checker.Result{Violations: []checker.Violation{violation("toolu_a", "Direct push")}})
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0] != b.Entries[0] {
+		t.Errorf("loaded %+v, want %+v", loaded.Entries, b.Entries)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load accepted unsupported version")
+	}
+}
@@ -0,0 +1,185 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// message is the text of one event in the main conversation.
+type message struct {
+	index int // position in t.Events
+	uuid  string
+	text  string
+}
+
+// humanMessages returns the messages typed by the user. Tool results,
+// subagent prompts, and sidechain messages are excluded.
+func humanMessages(t *transcript.Transcript) []message {
+	var msgs []message
+	for i, event := range t.Events {
+		ev, ok := event.(transcript.UserEvent)
+		if !ok || ev.ParentToolUseID != nil || ev.IsSidechain {
+			continue
+		}
+		var text []string
+		for _, content := range ev.Message.Content {
+			if content.Type == "text" {
+				text = append(text, content.Text)
+			}
+		}
+		if len(text) > 0 {
+			msgs = append(msgs, message{index: i, uuid: ev.UUID, text: strings.Join(text, "\n")})
+		}
+	}
+	return msgs
+}
+
+// assistantMessages returns the main agent's text responses.
+func assistantMessages(t *transcript.Transcript) []message {
+	var msgs []message
+	for i, event := range t.Events {
+		ev, ok := event.(transcript.AssistantEvent)
+		if !ok || ev.ParentToolUseID != nil || ev.IsSidechain {
+			continue
+		}
+		var text []string
+		for _, content := range ev.Message.Content {
+			if content.Type == "text" {
+				text = append(text, content.Text)
+			}
+		}
+		if len(text) > 0 {
+			msgs = append(msgs, message{index: i, uuid: ev.UUID, text: strings.Join(text, "\n")})
+		}
+	}
+	return msgs
+}
+
+// lastBefore returns the last message before event index i.
+func lastBefore(msgs []message, i int) (message, bool) {
+	var last message
+	found := false
+	for _, msg := range msgs {
+		if msg.index >= i {
+			break
+		}
+		last, found = msg, true
+	}
+	return last, found
+}
+
+var (
+	// affirmativePattern matches replies that grant approval.
+	affirmativePattern = regexp.MustCompile(`(?i)^\W*(y|yes|yep|yeah|yup|sure|ok|okay|approved?|go ahead|proceed|do it|lgtm|sounds good|confirmed?)\b`)
+
+	// negativePattern matches replies that refuse or defer approval.
+	negativePattern = regexp.MustCompile(`(?i)^\W*(n|no|nope|nah|don'?t|do not|stop|cancel|wait|hold on|not yet)\b`)
+
+	// negationPattern matches a negation anywhere in a message, as in
+	// "please don't delete anything".
+	negationPattern = regexp.MustCompile(`(?i)\b(no|not|never|without|avoid|isn'?t|don'?t|doesn'?t|shouldn'?t|can'?t|won'?t)\b`)
+)
+
+// isAffirmative reports whether a user reply grants approval.
+func isAffirmative(text string) bool {
+	return !negativePattern.MatchString(text) && affirmativePattern.MatchString(text)
+}
+
+// isNegative reports whether a user reply refuses approval.
+func isNegative(text string) bool {
+	return negativePattern.MatchString(text)
+}
+
+// reply classifies the user's answer to an approval request.
+type reply int
+
+const (
+	// replyAbsent means the user did not answer before the agent acted,
+	// as in non-interactive sessions.
+	replyAbsent reply = iota
+	replyAffirmative
+	replyNegative
+	// replyUnclear means the user answered with neither a yes nor a no.
+	replyUnclear
+)
+
+// classifyReply classifies the text of a user's reply.
+func classifyReply(text string) reply {
+	switch {
+	case isNegative(text):
+		return replyNegative
+	case isAffirmative(text):
+		return replyAffirmative
+	}
+	return replyUnclear
+}
+
+// approval pairs an agent's approval request with the user's reply.
+type approval struct {
+	request message
+	reply   reply
+}
+
+// findApproval returns the last assistant message before event index i
+// that matches one of patterns, paired with the first message the user
+// typed after it and before i.
+func findApproval(assistant, human []message, patterns []*regexp.Regexp, i int) (approval, bool) {
+	var a approval
+	found := false
+	for _, msg := range assistant {
+		if msg.index >= i {
+			break
+		}
+		for _, pattern := range patterns {
+			if pattern.MatchString(msg.text) {
+				a, found = approval{request: msg}, true
+				break
+			}
+		}
+	}
+	if !found {
+		return approval{}, false
+	}
+
+	for _, msg := range human {
+		if msg.index > a.request.index && msg.index < i {
+			a.reply = classifyReply(msg.text)
+			break
+		}
+	}
+	return a, true
+}
+
+// approvalProblem describes why an action lacks approval, given the
+// result of findApproval, and the severity of acting anyway. It reports
+// false if the user approved.
+func approvalProblem(a approval, found bool) (string, Severity, bool) {
+	if !found {
+		return "without requesting user approval", SeverityWarning, true
+	}
+	switch a.reply {
+	case replyAffirmative:
+		return "", 0, false
+	case replyNegative:
+		return "after the user declined", SeverityError, true
+	case replyUnclear:
+		return "without an affirmative reply to the approval request", SeverityWarning, true
+	}
+	return "before the user replied to the approval request", SeverityWarning, true
+}
+
+// permissionDenied returns the IDs of tool calls the user was prompted for
+// and declined, from the session's result events.
+func permissionDenied(t *transcript.Transcript) map[string]bool {
+	denied := make(map[string]bool)
+	for _, event := range t.Events {
+		if ev, ok := event.(transcript.ResultEvent); ok {
+			for _, d := range ev.PermissionDenials {
+				denied[d.ToolUseID] = true
+			}
+		}
+	}
+	return denied
+}
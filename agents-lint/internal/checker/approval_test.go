@@ -0,0 +1,74 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// say is assistant text in a conversation transcript.
+type say string
+
+// bash is a Bash tool call in a conversation transcript.
+type bash string
+
+// conversation builds a transcript from user messages (see userText),
+// assistant text, and Bash calls. Events get UUIDs e1, e2, ... and tool
+// calls IDs t1, t2, ....
+func conversation(turns ...any) *transcript.Transcript {
+	tr := &transcript.Transcript{SessionID: "s1"}
+	for i, turn := range turns {
+		uuid := fmt.Sprintf("e%d", i+1)
+		switch turn := turn.(type) {
+		case transcript.UserEvent:
+			turn.UUID = uuid
+			tr.Events = append(tr.Events, turn)
+		case say:
+			tr.Events = append(tr.Events, transcript.AssistantEvent{
+				Event:   transcript.Event{Type: "assistant", UUID: uuid},
+				Message: transcript.AssistantMessage{Content: []transcript.ContentBlock{{Type: "text", Text: string(turn)}}},
+			})
+		case bash:
+			id := fmt.Sprintf("t%d", len(tr.ToolCalls)+1)
+			input, _ := json.Marshal(BashInput{Command: string(turn)})
+			tr.Events = append(tr.Events, transcript.AssistantEvent{
+				Event:   transcript.Event{Type: "assistant", UUID: uuid},
+				Message: transcript.AssistantMessage{Content: []transcript.ContentBlock{{Type: "tool_use", ID: id, Name: "Bash", Input: input}}},
+			})
+			tr.ToolCalls = append(tr.ToolCalls, transcript.ToolCall{ID: id, Name: "Bash", Input: input, EventUUID: uuid})
+		default:
+			panic(fmt.Sprintf("conversation: unexpected turn %T", turn))
+		}
+	}
+	return tr
+}
+
+func TestReplyClassification(t *testing.T) {
+	tests := []struct {
+		text        string
+		affirmative bool
+		negative    bool
+	}{
+		{"Yes", true, false},
+		{"yes, go ahead", true, false},
+		{"OK!", true, false},
+		{"Go ahead and delete it", true, false},
+		{"No", false, true},
+		{"no, keep it", false, true},
+		{"Don't delete anything", false, true},
+		{"Wait, let me check first", false, true},
+		{"What does that file do?", false, false},
+		{"Yesterday's build is fine", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := isAffirmative(tt.text); got != tt.affirmative {
+			t.Errorf("isAffirmative(%q) = %v, want %v", tt.text, got, tt.affirmative)
+		}
+		if got := isNegative(tt.text); got != tt.negative {
+			t.Errorf("isNegative(%q) = %v, want %v", tt.text, got, tt.negative)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package checker
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&BackgroundMonitoring{})
+}
+
+// BackgroundMonitoring checks that monitoring runs in the background and is
+// polled with backoff for at most ten minutes.
+// Per AGENTS.md Rule 7: "Start monitor using Bash with `run_in_background: true`"
+// and "Poll for results using BashOutput with exponential backoff".
+type BackgroundMonitoring struct{}
+
+func (c *BackgroundMonitoring) ID() string {
+	return "background-monitoring"
+}
+
+func (c *BackgroundMonitoring) Description() string {
+	return "Ensures monitoring runs in the background and is polled with backoff for at most 10 minutes (Rule 7)"
+}
+
+const (
+	// maxMonitoringDuration is the Rule 7 maximum duration.
+	maxMonitoringDuration = 10 * time.Minute
+
+	// backoffCap is the Rule 7 maximum delay between checks.
+	backoffCap = 60 * time.Second
+)
+
+// unknownInterval marks a polling interval without timestamps or sleeps
+// to measure it by.
+const unknownInterval time.Duration = -1
+
+// backgroundIDPattern matches the shell ID in the result of a Bash call
+// run in the background.
+var backgroundIDPattern = regexp.MustCompile(`(?i)running in background with ID: (\S+)`)
+
+// bashOutputInput is the input of a BashOutput tool call.
+type bashOutputInput struct {
+	BashID  string `json:"bash_id"`
+	ShellID string `json:"shell_id"`
+}
+
+// backgroundShell is a command started with run_in_background and the
+// BashOutput calls polling it.
+type backgroundShell struct {
+	start      *transcript.ToolCall // nil if the starting call wasn't found
+	monitoring bool                 // the command watches something
+	polls      []transcript.ToolCall
+	intervals  []time.Duration // time between consecutive polls (unknownInterval if unknown)
+	slept      time.Duration   // sleeps since the last poll
+}
+
+func (c *BackgroundMonitoring) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	shells := make(map[string]*backgroundShell)
+	var order []string
+
+	shell := func(id string) *backgroundShell {
+		if s, ok := shells[id]; ok {
+			return s
+		}
+		s := &backgroundShell{}
+		shells[id] = s
+		order = append(order, id)
+		return s
+	}
+
+	for _, tc := range t.ToolCalls {
+		if tc.Name == "BashOutput" {
+			var input bashOutputInput
+			if err := json.Unmarshal(tc.Input, &input); err != nil {
+				continue
+			}
+			id := cmp.Or(input.BashID, input.ShellID)
+			s := shell(id)
+			if n := len(s.polls); n > 0 {
+				interval := s.slept
+				if interval == 0 {
+					interval = unknownInterval
+				}
+				if prev := s.polls[n-1]; !tc.Timestamp.IsZero() && !prev.Timestamp.IsZero() {
+					interval = tc.Timestamp.Sub(prev.Timestamp)
+				}
+				s.intervals = append(s.intervals, interval)
+			}
+			s.polls = append(s.polls, tc)
+			s.slept = 0
+			continue
+		}
+
+		input, cmds, ok := bashCommands(tc)
+		if !ok {
+			continue
+		}
+
+		if input.RunInBackground {
+			if m := backgroundIDPattern.FindStringSubmatch(tc.Result); m != nil {
+				s := shell(m[1])
+				s.start = &tc
+				s.monitoring = slices.ContainsFunc(cmds, isMonitoring)
+			}
+			continue
+		}
+
+		if d, ok := sleepDuration(cmds); ok {
+			for _, s := range shells {
+				s.slept += d
+			}
+		}
+
+		i := slices.IndexFunc(cmds, func(cmd SimpleCommand) bool { return isMonitoring(cmd) && !cmd.Background })
+		if i < 0 {
+			continue
+		}
+		monitor := cmds[i].Source
+		if loop := cmds[i].Loop; loop != nil {
+			monitor = loop.Source
+		}
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 7",
+			Severity:   SeverityWarning,
+			Message:    "Monitoring command run in the foreground; start it with run_in_background and poll with BashOutput",
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Context: map[string]string{
+				"command": truncate(input.Command, 100),
+				"monitor": truncate(monitor, 100),
+			},
+		})
+		if tc.Latency > maxMonitoringDuration {
+			violations = append(violations, c.overtime(tc, tc.Latency))
+		}
+	}
+
+	for _, id := range order {
+		s := shells[id]
+		if v, ok := c.checkPolling(id, s); ok {
+			violations = append(violations, v)
+		}
+		if s.start == nil || !s.monitoring || len(s.polls) == 0 {
+			continue
+		}
+		last := s.polls[len(s.polls)-1]
+		if s.start.Timestamp.IsZero() || last.Timestamp.IsZero() {
+			continue
+		}
+		if d := last.Timestamp.Sub(s.start.Timestamp); d > maxMonitoringDuration {
+			violations = append(violations, c.overtime(last, d))
+		}
+	}
+
+	return violations
+}
+
+// checkPolling reports the first poll of a background shell that came
+// sooner after the previous one than backoff allows.
+func (c *BackgroundMonitoring) checkPolling(id string, s *backgroundShell) (Violation, bool) {
+	for i := 1; i < len(s.intervals); i++ {
+		prev, curr := s.intervals[i-1], s.intervals[i]
+		if prev == unknownInterval || curr == unknownInterval {
+			continue
+		}
+
+		var message string
+		switch {
+		case curr < prev*4/5:
+			message = fmt.Sprintf("BashOutput polling interval shrank from %s to %s; use exponential backoff", prev.Round(time.Second), curr.Round(time.Second))
+		case curr < prev*3/2 && curr < backoffCap*4/5:
+			message = fmt.Sprintf("BashOutput polled at a constant interval of about %s; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)", curr.Round(time.Second))
+		default:
+			continue
+		}
+
+		poll := s.polls[i+1]
+		return Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 7",
+			Severity:   SeverityWarning,
+			Message:    message,
+			EventUUID:  poll.EventUUID,
+			ToolCallID: poll.ID,
+			Context: map[string]string{
+				"shell": id,
+				"polls": fmt.Sprint(len(s.polls)),
+			},
+		}, true
+	}
+	return Violation{}, false
+}
+
+// overtime reports monitoring that ran longer than the Rule 7 maximum.
+func (c *BackgroundMonitoring) overtime(tc transcript.ToolCall, d time.Duration) Violation {
+	return Violation{
+		CheckerID:  c.ID(),
+		Rule:       "Rule 7",
+		Severity:   SeverityWarning,
+		Message:    fmt.Sprintf("Monitoring ran for %s, over the 10-minute maximum", d.Round(time.Second)),
+		EventUUID:  tc.EventUUID,
+		ToolCallID: tc.ID,
+		Context: map[string]string{
+			"duration": d.Round(time.Second).String(),
+		},
+	}
+}
+
+// isMonitoring reports whether a command watches something until stopped:
+// a follow or watch mode, or any command in an infinite loop.
+func isMonitoring(cmd SimpleCommand) bool {
+	if cmd.Loop != nil && cmd.Loop.Infinite() {
+		return true
+	}
+
+	argv := cmd.Argv()
+	if len(argv) == 0 {
+		return false
+	}
+	args := argv[1:]
+	switch cmd.Program() {
+	case "watch":
+		return true
+	case "tail":
+		return hasFlag(args, "-f", "-F", "--follow")
+	case "journalctl":
+		// -o takes an attached value, as in -oshort-full
+		return hasExactFlag(args, "-f", "--follow")
+	case "docker", "podman":
+		sub, subArgs, ok := cmd.Subcommand(cmd.Program())
+		switch {
+		case !ok:
+			return false
+		case sub == "events":
+			return true
+		case sub == "logs":
+			return hasFlag(subArgs, "-f", "--follow")
+		}
+	case "gh":
+		sub, subArgs, ok := cmd.Subcommand("gh")
+		if !ok || len(subArgs) == 0 {
+			return false
+		}
+		switch sub + " " + subArgs[0] {
+		case "run watch":
+			return true
+		case "pr checks":
+			return hasFlag(subArgs[1:], "--watch")
+		}
+	case "kubectl":
+		sub, subArgs, ok := cmd.Subcommand("kubectl")
+		if !ok {
+			return false
+		}
+		if sub == "rollout" && len(subArgs) > 0 && subArgs[0] == "status" {
+			return !slices.Contains(subArgs, "--watch=false") && !slices.Contains(subArgs, "-w=false")
+		}
+		return hasExactFlag(subArgs, "-w", "--watch") && !slices.Contains(subArgs, "--watch=false")
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package checker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func TestBackgroundMonitoring_ID(t *testing.T) {
+	c := &BackgroundMonitoring{}
+	if c.ID() != "background-monitoring" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "background-monitoring")
+	}
+}
+
+func TestIsMonitoring(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"gh pr checks --watch", true},
+		{"gh pr checks 12", false},
+		{"gh run watch 123", true},
+		{"kubectl rollout status deploy/web", true},
+		{"kubectl -n prod rollout status deploy/web --watch=false", false},
+		{"kubectl get pods -w", true},
+		{"kubectl get pods", false},
+		{"kubectl get pods -owide", false},
+		{"kubectl get pods -o wide -w", true},
+		{"journalctl -u api -oshort-full", false},
+		{"journalctl -u api -f", true},
+		{"tail -f app.log", true},
+		{"tail -n 50 app.log", false},
+		{"docker logs --follow web", true},
+		{"docker logs web", false},
+		{"watch -n 5 kubectl get pods", true},
+		{"while true; do gh pr checks; sleep 30; done", true},
+		{"while ! gh pr checks; do sleep 30; done", false},
+	}
+
+	for _, tt := range tests {
+		cmds := ParseShell(tt.command)
+		if got := slices.ContainsFunc(cmds, isMonitoring); got != tt.want {
+			t.Errorf("isMonitoring(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+// backgroundBash is a Bash call run with run_in_background that started
+// background shell id.
+func backgroundBash(callID, command, id string) transcript.ToolCall {
+	return transcript.ToolCall{
+		ID:        callID,
+		Name:      "Bash",
+		EventUUID: "e-" + callID,
+		Input:     toRawJSON(map[string]any{"command": command, "run_in_background": true}),
+		Result:    "Command running in background with ID: " + id,
+	}
+}
+
+// bashOutput polls background shell id.
+func bashOutput(callID, id string) transcript.ToolCall {
+	return transcript.ToolCall{
+		ID:        callID,
+		Name:      "BashOutput",
+		EventUUID: "e-" + callID,
+		Input:     toRawJSON(map[string]any{"bash_id": id}),
+	}
+}
+
+// pollsAt starts a monitor in the background at time 0 and polls it at
+// the given offsets.
+func pollsAt(offsets ...time.Duration) []transcript.ToolCall {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := backgroundBash("t0", "gh pr checks --watch", "abc")
+	start.Timestamp = base
+	calls := []transcript.ToolCall{start}
+	for i, d := range offsets {
+		poll := bashOutput(fmt.Sprintf("t%d", i+1), "abc")
+		poll.Timestamp = base.Add(d)
+		calls = append(calls, poll)
+	}
+	return calls
+}
+
+func TestBackgroundMonitoring_Check(t *testing.T) {
+	s := time.Second
+
+	tests := []struct {
+		name  string
+		calls []transcript.ToolCall
+		want  []string // message prefixes
+	}{
+		{
+			name:  "exponential polling",
+			calls: pollsAt(0, 5*s, 15*s, 35*s, 75*s, 135*s, 195*s),
+		},
+		{
+			name:  "constant polling",
+			calls: pollsAt(0, 10*s, 20*s, 30*s),
+			want:  []string{"BashOutput polled at a constant interval of about 10s"},
+		},
+		{
+			name:  "shrinking polling",
+			calls: pollsAt(0, 40*s, 60*s),
+			want:  []string{"BashOutput polling interval shrank from 40s to 20s"},
+		},
+		{
+			name:  "capped polling",
+			calls: pollsAt(0, 60*s, 120*s, 180*s),
+		},
+		{
+			name:  "over ten minutes",
+			calls: pollsAt(0, 5*s, 15*s, 35*s, 75*s, 11*time.Minute),
+			want:  []string{"Monitoring ran for 11m0s, over the 10-minute maximum"},
+		},
+		{
+			name: "constant sleeps between polls",
+			calls: []transcript.ToolCall{
+				backgroundBash("t0", "tail -f build.log", "abc"),
+				bashOutput("t1", "abc"),
+				bashCall("t2", "e2", "sleep 10"),
+				bashOutput("t3", "abc"),
+				bashCall("t4", "e4", "sleep 10"),
+				bashOutput("t5", "abc"),
+			},
+			want: []string{"BashOutput polled at a constant interval of about 10s"},
+		},
+		{
+			name: "no timing information",
+			calls: []transcript.ToolCall{
+				backgroundBash("t0", "tail -f build.log", "abc"),
+				bashOutput("t1", "abc"),
+				bashOutput("t2", "abc"),
+				bashOutput("t3", "abc"),
+			},
+		},
+		{
+			name:  "foreground watch",
+			calls: []transcript.ToolCall{bashCall("t1", "e1", "gh pr checks --watch")},
+			want:  []string{"Monitoring command run in the foreground"},
+		},
+		{
+			name:  "foreground loop",
+			calls: []transcript.ToolCall{bashCall("t1", "e1", "while true; do kubectl get pods; sleep 5; done")},
+			want:  []string{"Monitoring command run in the foreground"},
+		},
+		{
+			name:  "shell background job",
+			calls: []transcript.ToolCall{bashCall("t1", "e1", "tail -f app.log > /tmp/log &")},
+		},
+		{
+			name:  "one-off status check",
+			calls: []transcript.ToolCall{bashCall("t1", "e1", "gh pr checks")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &BackgroundMonitoring{}
+			violations := c.Check(&transcript.Transcript{ToolCalls: tt.calls})
+
+			if len(violations) != len(tt.want) {
+				t.Fatalf("got %d violations, want %d: %v", len(violations), len(tt.want), violations)
+			}
+			for i, v := range violations {
+				if !strings.HasPrefix(v.Message, tt.want[i]) {
+					t.Errorf("violation %d = %q, want prefix %q", i, v.Message, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBackgroundMonitoring_LongForegroundWatch(t *testing.T) {
+	tc := bashCall("t1", "e1", "kubectl rollout status deploy/web")
+	tc.Latency = 12 * time.Minute
+
+	c := &BackgroundMonitoring{}
+	violations := c.Check(&transcript.Transcript{ToolCalls: []transcript.ToolCall{tc}})
+	if len(violations) != 2 || !strings.HasPrefix(violations[1].Message, "Monitoring ran for 12m0s") {
+		t.Errorf("expected foreground and duration violations, got %v", violations)
+	}
+}
@@ -0,0 +1,183 @@
+package checker
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&BeadsCommitted{})
+}
+
+// BeadsCommitted checks that changes to the beads database are committed,
+// and that commits reference the issue being worked on.
+// Per AGENTS.md Rule 6: "Always commit `.beads/issues.jsonl` with code changes."
+type BeadsCommitted struct{}
+
+func (c *BeadsCommitted) ID() string {
+	return "beads-committed"
+}
+
+func (c *BeadsCommitted) Description() string {
+	return "Ensures bd changes are committed and commits reference the in-progress issue (Rule 6)"
+}
+
+// bdMutations are bd subcommands that change the beads database.
+var bdMutations = []string{"create", "update", "close", "dep"}
+
+// bdValueFlags are bd update and close options that take a separate value.
+var bdValueFlags = []string{
+	"-s", "--status", "-p", "--priority", "-a", "--assignee", "-t", "--type",
+	"-d", "--description", "--title", "--notes", "--design", "-r", "--reason",
+}
+
+func (c *BeadsCommitted) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	// bdChange is a bd command that changed the database.
+	type bdChange struct {
+		tc  transcript.ToolCall
+		cmd SimpleCommand
+	}
+	var unsaved *bdChange  // first bd change not yet committed
+	var unstaged *bdChange // first bd change since .beads/ was last staged
+	staged := false        // .beads/ staged since the last commit
+	var inProgress []string
+
+	for _, tc := range t.ToolCalls {
+		if tc.IsError {
+			continue
+		}
+		input, cmds, ok := bashCommands(tc)
+		if !ok {
+			continue
+		}
+
+		for _, cmd := range cmds {
+			if sub, args, ok := cmd.Subcommand("bd"); ok {
+				if sub == "sync" {
+					// bd sync commits the database itself
+					unsaved, unstaged = nil, nil
+				}
+				if slices.Contains(bdMutations, sub) {
+					change := &bdChange{tc, cmd}
+					if unsaved == nil {
+						unsaved = change
+					}
+					if unstaged == nil {
+						unstaged = change
+					}
+				}
+				inProgress = updateInProgress(inProgress, sub, args)
+				continue
+			}
+
+			sub, args, ok := cmd.Subcommand("git")
+			if !ok {
+				continue
+			}
+			switch sub {
+			case "add":
+				if stagesBeads(args) {
+					staged, unstaged = true, nil
+				}
+			case "commit":
+				// Changes made after .beads/ was staged aren't committed
+				// unless the commit stages everything itself
+				switch {
+				case hasFlag(args, "-a", "--all"):
+					unsaved, unstaged = nil, nil
+				case staged:
+					unsaved = unstaged
+				}
+				staged = false
+
+				_, words, _ := cmd.subcommandWords("git")
+				msgs := commitMessages(words)
+				if len(inProgress) == 0 || len(msgs) == 0 || mentionsAny(msgs, inProgress) {
+					continue
+				}
+				violations = append(violations, Violation{
+					CheckerID:  c.ID(),
+					Rule:       "Rule 6",
+					Severity:   SeverityWarning,
+					Message:    "Commit message omits the in-progress issue ID",
+					EventUUID:  tc.EventUUID,
+					ToolCallID: tc.ID,
+					Context: map[string]string{
+						"command": truncate(input.Command, 100),
+						"issues":  strings.Join(inProgress, ", "),
+					},
+				})
+			}
+		}
+	}
+
+	if unsaved != nil {
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 6",
+			Severity:   SeverityWarning,
+			Message:    "Beads database changed but .beads/ was not committed",
+			EventUUID:  unsaved.tc.EventUUID,
+			ToolCallID: unsaved.tc.ID,
+			Context: map[string]string{
+				"command": truncate(unsaved.cmd.Source, 100),
+			},
+		})
+	}
+
+	return violations
+}
+
+// updateInProgress applies a bd command to the set of in-progress issues.
+func updateInProgress(ids []string, sub string, args []string) []string {
+	switch sub {
+	case "update":
+		status, ok := flagValue(args, "-s", "--status")
+		if !ok {
+			return ids
+		}
+		for _, id := range positionalArgs(args, bdValueFlags...) {
+			ids = slices.DeleteFunc(ids, func(s string) bool { return s == id })
+			if status == "in_progress" {
+				ids = append(ids, id)
+			}
+		}
+	case "close":
+		for _, id := range positionalArgs(args, bdValueFlags...) {
+			ids = slices.DeleteFunc(ids, func(s string) bool { return s == id })
+		}
+	}
+	return ids
+}
+
+// stagesBeads reports whether `git add args` stages the .beads directory,
+// either by naming it or by staging everything.
+func stagesBeads(args []string) bool {
+	if hasFlag(args, "-A", "--all", "-u", "--update") {
+		return true
+	}
+	for _, p := range positionalArgs(args, "--chmod", "--pathspec-from-file") {
+		p = filepath.Clean(p)
+		if p == "." || p == ":/" || p == "*" || strings.Contains("/"+p+"/", "/.beads/") {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionsAny reports whether any commit message contains one of ids.
+func mentionsAny(msgs []Word, ids []string) bool {
+	for _, msg := range msgs {
+		for _, id := range ids {
+			if strings.Contains(msg.Value, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBeadsCommitted_ID(t *testing.T) {
+	c := &BeadsCommitted{}
+	if c.ID() != "beads-committed" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "beads-committed")
+	}
+}
+
+func TestStagesBeads(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"git add .", true},
+		{"git add -A", true},
+		{"git add --all", true},
+		{"git add -u", true},
+		{"git add .beads/issues.jsonl", true},
+		{"git add ./.beads/", true},
+		{"git add src/main.go .beads", true},
+		{"git add /repo/.beads/issues.jsonl", true},
+		{"git add src/main.go", false},
+		{"git add -p src/", false},
+		{"git add beads.go", false},
+	}
+
+	for _, tt := range tests {
+		_, args, _ := ParseShell(tt.command)[0].Subcommand("git")
+		if got := stagesBeads(args); got != tt.want {
+			t.Errorf("stagesBeads(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestBeadsCommitted_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []bash
+		want     []string // violation messages
+	}{
+		{
+			name: "beads committed with code",
+			commands: []bash{
+				`bd create "Fix login" -p 1`,
+				"git add src/login.go .beads/issues.jsonl",
+				`git commit -m "Fix login"`,
+			},
+		},
+		{
+			name: "add all",
+			commands: []bash{
+				"bd close AGENTS-3",
+				`git add -A && git commit -m "Close AGENTS-3"`,
+			},
+		},
+		{
+			name: "commit all tracked",
+			commands: []bash{
+				"bd dep add AGENTS-2 AGENTS-1",
+				`git commit -am "Link AGENTS-2 to AGENTS-1"`,
+			},
+		},
+		{
+			name: "bd sync",
+			commands: []bash{
+				`bd create "Refactor"`,
+				"bd sync",
+			},
+		},
+		{
+			name: "read-only bd commands",
+			commands: []bash{
+				"bd list",
+				"bd ready",
+				"bd show AGENTS-1",
+			},
+		},
+		{
+			name: "beads never staged",
+			commands: []bash{
+				`bd create "Fix login"`,
+				"git add src/login.go",
+				`git commit -m "Fix login"`,
+			},
+			want: []string{"Beads database changed but .beads/ was not committed"},
+		},
+		{
+			name: "staged before the change",
+			commands: []bash{
+				"git add .",
+				`git commit -m "Initial"`,
+				"bd close AGENTS-1",
+			},
+			want: []string{"Beads database changed but .beads/ was not committed"},
+		},
+		{
+			name: "staging carries to next commit only",
+			commands: []bash{
+				`bd create "Task"`,
+				"git add .beads/",
+				"git stash",
+				`git commit -m "Add task"`,
+				`bd create "Another"`,
+				`git commit -m "Nothing staged"`,
+			},
+			want: []string{"Beads database changed but .beads/ was not committed"},
+		},
+		{
+			name: "changed after staging",
+			commands: []bash{
+				`bd create "Fix login"`,
+				"git add .beads/issues.jsonl",
+				"bd update AGENTS-1 --status in_progress",
+				`git commit -m "Add AGENTS-1"`,
+			},
+			want: []string{"Beads database changed but .beads/ was not committed"},
+		},
+		{
+			name: "restaged after the change",
+			commands: []bash{
+				`bd create "Fix login"`,
+				"git add .beads/issues.jsonl",
+				"bd close AGENTS-1",
+				"git add .beads/issues.jsonl",
+				`git commit -m "Add and close AGENTS-1"`,
+			},
+		},
+		{
+			name: "commit references in-progress issue",
+			commands: []bash{
+				"bd update AGENTS-7 --status in_progress",
+				`git add . && git commit -m "AGENTS-7: Add parser"`,
+			},
+		},
+		{
+			name: "commit omits in-progress issue",
+			commands: []bash{
+				"bd update AGENTS-7 --status in_progress",
+				`git add . && git commit -m "Add parser"`,
+			},
+			want: []string{"Commit message omits the in-progress issue ID"},
+		},
+		{
+			name: "issue closed before commit",
+			commands: []bash{
+				"bd update AGENTS-7 -s in_progress",
+				`bd close AGENTS-7 --reason "done"`,
+				`git add . && git commit -m "Add parser"`,
+			},
+		},
+		{
+			name: "issue moved out of progress",
+			commands: []bash{
+				"bd update AGENTS-7 --status in_progress",
+				"bd update AGENTS-7 --status blocked",
+				`git add . && git commit -m "Park parser work"`,
+			},
+		},
+		{
+			name: "commit without message flag",
+			commands: []bash{
+				"bd update AGENTS-7 --status in_progress",
+				"git add .",
+				"git commit --amend --no-edit",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			turns := make([]any, len(tt.commands))
+			for i, cmd := range tt.commands {
+				turns[i] = cmd
+			}
+
+			c := &BeadsCommitted{}
+			var got []string
+			for _, v := range c.Check(conversation(turns...)) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeadsCommitted_SkipsFailedCalls(t *testing.T) {
+	tr := conversation(bash(`bd create "Task"`), bash("git add ."), bash(`git commit -m "Add task"`))
+	tr.ToolCalls[2].IsError = true
+
+	c := &BeadsCommitted{}
+	violations := c.Check(tr)
+	if len(violations) != 1 || violations[0].ToolCallID != "t1" {
+		t.Errorf("expected a violation for the uncommitted bd create, got %v", violations)
+	}
+}
@@ -0,0 +1,203 @@
+package checker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&BranchNaming{})
+}
+
+// BranchNaming checks that work on a bead happens on a branch named after it.
+// Per AGENTS.md Rule 3: "Create a new git branch for each bead issue."
+// and "Format: `[issue-id]`".
+type BranchNaming struct {
+	// IssuePrefix is the bead issue ID prefix, such as "AGENTS". If empty,
+	// it is read from .beads/config.yaml in the session's directory or
+	// its parents.
+	IssuePrefix string
+}
+
+func (c *BranchNaming) ID() string {
+	return "branch-naming"
+}
+
+func (c *BranchNaming) Description() string {
+	return "Ensures each bead issue is worked on in a branch named after its ID (Rule 3)"
+}
+
+// Configure sets options from the project configuration file.
+func (c *BranchNaming) Configure(decode func(any) error) error {
+	var opts struct {
+		IssuePrefix string `yaml:"issue_prefix"`
+	}
+	opts.IssuePrefix = c.IssuePrefix
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	c.IssuePrefix = opts.IssuePrefix
+	return nil
+}
+
+func (c *BranchNaming) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	prefix := c.IssuePrefix
+	if prefix == "" {
+		prefix = beadsIssuePrefix(t.CWD)
+	}
+	var idPattern *regexp.Regexp
+	if prefix != "" {
+		idPattern = regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `-[0-9A-Za-z]+(\.[0-9]+)*$`)
+	}
+
+	var inProgress []string
+	started := make(map[string]bool) // issues moved to in_progress
+	created := make(map[string]bool) // branches created
+	flagged := make(map[string]bool) // branches already reported
+	onIssueBranch := false           // an issue branch has been checked out
+
+	isIssue := func(branch string) bool {
+		return started[branch] || idPattern != nil && idPattern.MatchString(branch)
+	}
+	violation := func(tc transcript.ToolCall, cmd SimpleCommand, message string, context map[string]string) {
+		context["command"] = truncate(cmd.Source, 100)
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 3",
+			Severity:   SeverityWarning,
+			Message:    message,
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Context:    context,
+		})
+	}
+
+	replaySession(t, func(tc transcript.ToolCall, before State, ops []GitOp) bool {
+		if tc.IsError {
+			return true
+		}
+		_, cmds, ok := bashCommands(tc)
+		if !ok {
+			return true
+		}
+
+		branch := before.Branch
+		if isIssue(branch) {
+			onIssueBranch = true
+		}
+
+		for _, cmd := range cmds {
+			if sub, args, ok := cmd.Subcommand("bd"); ok {
+				for _, id := range startedIssues(sub, args) {
+					started[id] = true
+					switch {
+					case branch == id:
+						onIssueBranch = true
+					case created[id] || branch == "":
+						// Worked on in a worktree, or the branch is unknown
+					case protectedBranches[branch]:
+						violation(tc, cmd, fmt.Sprintf("Started work on %s while on %s; create branch %s first", id, branch, id),
+							map[string]string{"issue": id, "branch": branch})
+					case !flagged[branch]:
+						flagged[branch] = true
+						violation(tc, cmd, fmt.Sprintf("Started work on %s on branch %s; the branch must be named %s", id, branch, id),
+							map[string]string{"issue": id, "branch": branch})
+					}
+				}
+				inProgress = updateInProgress(inProgress, sub, args)
+				continue
+			}
+
+			// ops are in the same order as the git commands that made them
+			if len(ops) == 0 || ops[0].Command.Source != cmd.Source {
+				continue
+			}
+			op := ops[0]
+			ops = ops[1:]
+
+			switch op.Kind {
+			case GitOpCheckout, GitOpWorktreeAdd, GitOpBranchCreate:
+				if op.Kind == GitOpCheckout && op.Target != "" {
+					branch = op.Target
+				}
+				if isIssue(op.Target) {
+					onIssueBranch = true
+				}
+				if !op.Create && op.Kind != GitOpBranchCreate {
+					continue
+				}
+				created[op.Target] = true
+
+				var message string
+				switch {
+				case len(inProgress) > 0 && !slices.Contains(inProgress, op.Target):
+					message = fmt.Sprintf("Branch %s does not match the in-progress issue %s", op.Target, strings.Join(inProgress, ", "))
+				case len(inProgress) == 0 && idPattern != nil && !idPattern.MatchString(op.Target):
+					message = fmt.Sprintf("Branch %s is not named after a bead issue ID (%s-...)", op.Target, prefix)
+				default:
+					continue
+				}
+				flagged[op.Target] = true
+				violation(tc, cmd, message, map[string]string{"branch": op.Target})
+
+			case GitOpCommit:
+				if onIssueBranch || op.Branch == "" || protectedBranches[op.Branch] || isIssue(op.Branch) {
+					// Commits on main are reported by git-branch
+					continue
+				}
+				violation(tc, cmd, fmt.Sprintf("Commit on branch %s before creating a branch for a bead issue", op.Branch),
+					map[string]string{"branch": op.Branch})
+			}
+		}
+		return true
+	})
+
+	return violations
+}
+
+// startedIssues returns the issues a bd command moves to in_progress.
+func startedIssues(sub string, args []string) []string {
+	if sub != "update" {
+		return nil
+	}
+	if status, ok := flagValue(args, "-s", "--status"); !ok || status != "in_progress" {
+		return nil
+	}
+	return positionalArgs(args, bdValueFlags...)
+}
+
+// beadsIssuePrefix returns the issue-prefix setting of the nearest
+// .beads/config.yaml in dir or its parents, or "" if there is none.
+func beadsIssuePrefix(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, ".beads", "config.yaml"))
+		if err == nil {
+			var cfg struct {
+				IssuePrefix string `yaml:"issue-prefix"`
+			}
+			if yaml.Unmarshal(data, &cfg) != nil {
+				return ""
+			}
+			return cfg.IssuePrefix
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
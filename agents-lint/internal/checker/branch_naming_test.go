@@ -0,0 +1,154 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBranchNaming_ID(t *testing.T) {
+	c := &BranchNaming{}
+	if c.ID() != "branch-naming" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "branch-naming")
+	}
+}
+
+func TestBranchNaming_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []bash
+		want     []string // violation messages
+	}{
+		{
+			name: "documented workflow",
+			commands: []bash{
+				"git checkout main",
+				"git checkout -b AGENTS-42",
+				"bd update AGENTS-42 -s in_progress",
+				`git add . && git commit -m "Implement feature (AGENTS-42)"`,
+			},
+		},
+		{
+			name: "branch after starting issue",
+			commands: []bash{
+				"git checkout feature-x",
+				"bd update AGENTS-42 --status in_progress",
+				"git switch -c AGENTS-42",
+				`git commit -am "Fix"`,
+			},
+			want: []string{"Started work on AGENTS-42 on branch feature-x; the branch must be named AGENTS-42"},
+		},
+		{
+			name: "worktree branch",
+			commands: []bash{
+				"git checkout main",
+				"git worktree add -b AGENTS-7 ../AGENTS-7",
+				"bd update AGENTS-7 --status in_progress",
+			},
+		},
+		{
+			name: "branch does not match in-progress issue",
+			commands: []bash{
+				"git checkout main",
+				"bd update AGENTS-42 --status in_progress",
+				"git checkout -b fix-login",
+			},
+			want: []string{
+				"Started work on AGENTS-42 while on main; create branch AGENTS-42 first",
+				"Branch fix-login does not match the in-progress issue AGENTS-42",
+			},
+		},
+		{
+			name: "branch not named after an issue",
+			commands: []bash{
+				"git checkout -b feature/login",
+				"bd update AGENTS-42 --status in_progress",
+			},
+			want: []string{"Branch feature/login is not named after a bead issue ID (AGENTS-...)"},
+		},
+		{
+			name: "commit before issue branch",
+			commands: []bash{
+				"git checkout develop",
+				`git commit -am "Quick fix"`,
+				"git checkout -b AGENTS-3",
+				`git commit -am "AGENTS-3: Fix"`,
+			},
+			want: []string{"Commit on branch develop before creating a branch for a bead issue"},
+		},
+		{
+			name: "commit on main is left to git-branch",
+			commands: []bash{
+				"git checkout main",
+				`git commit -am "Fix"`,
+			},
+		},
+		{
+			name: "unknown branch",
+			commands: []bash{
+				"bd update AGENTS-42 --status in_progress",
+				`git commit -am "Fix"`,
+			},
+		},
+		{
+			name: "other status",
+			commands: []bash{
+				"git checkout main",
+				"bd update AGENTS-1 --status open",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			turns := make([]any, len(tt.commands))
+			for i, cmd := range tt.commands {
+				turns[i] = cmd
+			}
+			tr := conversation(turns...)
+			tr.CWD = "/repo"
+
+			c := &BranchNaming{IssuePrefix: "AGENTS"}
+			var got []string
+			for _, v := range c.Check(tr) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchNaming_NoPrefix(t *testing.T) {
+	tr := conversation(bash("git checkout -b feature/login"), bash(`git commit -am "Fix"`))
+	tr.CWD = t.TempDir()
+
+	c := &BranchNaming{}
+	if violations := c.Check(tr); len(violations) != 1 {
+		t.Errorf("expected only the commit to be flagged without an issue prefix, got %v", violations)
+	}
+}
+
+func TestBeadsIssuePrefix(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "# Issue prefix\nissue-prefix: \"AGENTS\"\n# no-db: false\n"
+	if err := os.WriteFile(filepath.Join(root, ".beads", "config.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := beadsIssuePrefix(sub); got != "AGENTS" {
+		t.Errorf("beadsIssuePrefix(subdir) = %q, want %q", got, "AGENTS")
+	}
+	if got := beadsIssuePrefix(""); got != "" {
+		t.Errorf("beadsIssuePrefix(\"\") = %q, want empty", got)
+	}
+}
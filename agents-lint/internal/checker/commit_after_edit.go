@@ -1,8 +1,8 @@
 package checker
 
 import (
-	"encoding/json"
-	"regexp"
+	"fmt"
+	"strconv"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
 )
@@ -27,6 +27,22 @@ func (c *CommitAfterEdit) Description() string {
 	return "Ensures file edits are followed by git commits (Rule 6)"
 }
 
+// Configure sets options from the project configuration file.
+func (c *CommitAfterEdit) Configure(decode func(any) error) error {
+	var opts struct {
+		MaxToolCallsBeforeCommit int `yaml:"max_tool_calls_before_commit"`
+	}
+	opts.MaxToolCallsBeforeCommit = c.MaxToolCallsBeforeCommit
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	if opts.MaxToolCallsBeforeCommit < 0 {
+		return fmt.Errorf("max_tool_calls_before_commit must not be negative")
+	}
+	c.MaxToolCallsBeforeCommit = opts.MaxToolCallsBeforeCommit
+	return nil
+}
+
 // editTools are tools that modify files
 var editTools = map[string]bool{
 	"Edit":         true,
@@ -34,9 +50,6 @@ var editTools = map[string]bool{
 	"NotebookEdit": true,
 }
 
-// gitCommitPattern matches git commit commands
-var gitCommitCmdPattern = regexp.MustCompile(`git\s+commit`)
-
 func (c *CommitAfterEdit) Check(t *transcript.Transcript) []Violation {
 	var violations []Violation
 
@@ -45,10 +58,23 @@ func (c *CommitAfterEdit) Check(t *transcript.Transcript) []Violation {
 		maxCalls = 15 // Default window
 	}
 
+	// Each agent is responsible for committing its own edits, so a subagent's
+	// edit is measured against the subagent's own tool calls.
+	for agent := range t.AgentTree().All() {
+		violations = append(violations, c.checkAgent(agent, maxCalls)...)
+	}
+
+	return violations
+}
+
+// checkAgent applies the commit window to a single agent's tool calls.
+func (c *CommitAfterEdit) checkAgent(agent *transcript.Agent, maxCalls int) []Violation {
+	var violations []Violation
+
 	// Track uncommitted edits
 	var pendingEdits []pendingEdit
 
-	for i, tc := range t.ToolCalls {
+	for i, tc := range agent.ToolCalls {
 		// Check if this is an edit tool
 		if editTools[tc.Name] {
 			pendingEdits = append(pendingEdits, pendingEdit{
@@ -61,17 +87,10 @@ func (c *CommitAfterEdit) Check(t *transcript.Transcript) []Violation {
 		}
 
 		// Check if this is a git commit
-		if tc.Name == "Bash" {
-			var input BashInput
-			if err := json.Unmarshal(tc.Input, &input); err != nil {
-				continue
-			}
-
-			if gitCommitCmdPattern.MatchString(input.Command) {
-				// Commit found - clear pending edits
-				pendingEdits = nil
-				continue
-			}
+		if _, cmds, ok := bashCommands(tc); ok && anyGit(cmds, "commit") {
+			// Commit found - clear pending edits
+			pendingEdits = nil
+			continue
 		}
 
 		// Check if any pending edits are too old
@@ -84,10 +103,11 @@ func (c *CommitAfterEdit) Check(t *transcript.Transcript) []Violation {
 					Message:    "File edit not followed by git commit within reasonable window",
 					EventUUID:  edit.eventUUID,
 					ToolCallID: edit.toolCallID,
+					Agent:      agent.Name(),
 					Context: map[string]string{
-						"tool":           edit.toolName,
-						"calls_since":    string(rune('0' + (i - edit.index))),
-						"max_calls":      string(rune('0' + maxCalls)),
+						"tool":        edit.toolName,
+						"calls_since": strconv.Itoa(i - edit.index),
+						"max_calls":   strconv.Itoa(maxCalls),
 					},
 				})
 				// Remove this edit from pending to avoid duplicate violations
@@ -105,6 +125,7 @@ func (c *CommitAfterEdit) Check(t *transcript.Transcript) []Violation {
 			Message:    "File edit not committed by end of session",
 			EventUUID:  edit.eventUUID,
 			ToolCallID: edit.toolCallID,
+			Agent:      agent.Name(),
 			Context: map[string]string{
 				"tool": edit.toolName,
 			},
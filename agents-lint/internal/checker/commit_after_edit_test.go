@@ -147,3 +147,72 @@ func TestCommitAfterEdit_Registered(t *testing.T) {
 		t.Error("commit-after-edit checker not registered")
 	}
 }
+
+func TestCommitAfterEdit_SubagentEditAttributedToSubagent(t *testing.T) {
+	editInput, _ := json.Marshal(map[string]string{"file_path": "/test/file.go"})
+	commitInput, _ := json.Marshal(BashInput{Command: "git commit -am \"Update\""})
+	taskInput, _ := json.Marshal(map[string]string{"subagent_type": "general-purpose", "prompt": "edit"})
+
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", Name: "Task", Input: taskInput, EventUUID: "e1"},
+			{ID: "t2", Name: "Edit", Input: editInput, EventUUID: "e2", ParentToolUseID: "t1"},
+			{ID: "t3", Name: "Edit", Input: editInput, EventUUID: "e3"},
+			{ID: "t4", Name: "Bash", Input: commitInput, EventUUID: "e4"},
+		},
+	}
+
+	c := &CommitAfterEdit{}
+	violations := c.Check(tr)
+
+	// The main agent's commit covers its own edit, not the subagent's
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation for uncommitted subagent edit, got %d", len(violations))
+	}
+	if violations[0].ToolCallID != "t2" {
+		t.Errorf("ToolCallID = %q, want %q", violations[0].ToolCallID, "t2")
+	}
+	if violations[0].Agent != "general-purpose (t1)" {
+		t.Errorf("Agent = %q, want %q", violations[0].Agent, "general-purpose (t1)")
+	}
+}
+
+func TestCommitAfterEdit_SubagentCommitsOwnEdit(t *testing.T) {
+	editInput, _ := json.Marshal(map[string]string{"file_path": "/test/file.go"})
+	commitInput, _ := json.Marshal(BashInput{Command: "git commit -am \"Update\""})
+	taskInput, _ := json.Marshal(map[string]string{"subagent_type": "general-purpose", "prompt": "edit"})
+
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", Name: "Task", Input: taskInput, EventUUID: "e1"},
+			{ID: "t2", Name: "Edit", Input: editInput, EventUUID: "e2", ParentToolUseID: "t1"},
+			{ID: "t3", Name: "Bash", Input: commitInput, EventUUID: "e3", ParentToolUseID: "t1"},
+		},
+	}
+
+	c := &CommitAfterEdit{}
+	violations := c.Check(tr)
+
+	if len(violations) != 0 {
+		t.Errorf("expected 0 violations when subagent commits its own edit, got %d", len(violations))
+	}
+}
+
+func TestCommitAfterEdit_QuotedCommitIsNotCommit(t *testing.T) {
+	editInput, _ := json.Marshal(map[string]string{"file_path": "/test/file.go"})
+	echoInput, _ := json.Marshal(BashInput{Command: `echo "remember to git commit"`})
+
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", Name: "Edit", Input: editInput, EventUUID: "e1"},
+			{ID: "t2", Name: "Bash", Input: echoInput, EventUUID: "e2"},
+		},
+	}
+
+	c := &CommitAfterEdit{}
+	violations := c.Check(tr)
+
+	if len(violations) != 1 {
+		t.Errorf("expected 1 violation when commit only appears in a string, got %d", len(violations))
+	}
+}
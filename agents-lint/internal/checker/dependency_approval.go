@@ -0,0 +1,246 @@
+package checker
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&DependencyApproval{})
+}
+
+// DependencyApproval checks that the user approves new dependencies before
+// they are installed or added to a manifest.
+// Per AGENTS.md Rule 4: "Dependencies needed for ... Approve installation? [Yes/No]"
+type DependencyApproval struct{}
+
+func (c *DependencyApproval) ID() string {
+	return "dependency-approval"
+}
+
+func (c *DependencyApproval) Description() string {
+	return "Ensures the user approves installing dependencies (Rule 4)"
+}
+
+// dependencyRequestPatterns match a request to approve dependencies.
+var dependencyRequestPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)dependenc(y|ies)\s+needed`),
+	regexp.MustCompile(`(?i)approve\s+(the\s+)?install(ation)?\s*\?`),
+}
+
+// dependencyManifests are files listing a project's dependencies.
+var dependencyManifests = []string{"package.json", "go.mod", "requirements.txt", "Cargo.toml"}
+
+func (c *DependencyApproval) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	human := humanMessages(t)
+	assistant := assistantMessages(t)
+	positions := eventPositions(t)
+
+	for agent := range t.AgentTree().All() {
+		for _, tc := range agent.ToolCalls {
+			change, packages, context, ok := dependencyChange(tc)
+			if !ok {
+				continue
+			}
+
+			a, found := findApproval(assistant, human, dependencyRequestPatterns, positions[tc.ID])
+			problem, severity, ok := approvalProblem(a, found)
+			if !ok {
+				// An approval only covers the packages it asked about
+				missing := unrequested(a.request.text, packages)
+				if len(missing) == 0 {
+					continue
+				}
+				problem, severity = "without approval for "+strings.Join(missing, ", ")+", which the approval request didn't name", SeverityWarning
+			}
+
+			violations = append(violations, Violation{
+				CheckerID:  c.ID(),
+				Rule:       "Rule 4",
+				Severity:   severity,
+				Message:    change + " " + problem,
+				EventUUID:  tc.EventUUID,
+				ToolCallID: tc.ID,
+				Agent:      agent.Name(),
+				Context:    context,
+			})
+		}
+	}
+
+	return violations
+}
+
+// dependencyChange reports whether a tool call installs a dependency or
+// adds one to a dependency manifest, describing the change for a
+// violation and naming the packages added where known.
+func dependencyChange(tc transcript.ToolCall) (string, []string, map[string]string, bool) {
+	if editTools[tc.Name] {
+		path, writes := writtenContent(tc)
+		manifest := filepath.Base(path)
+		if !slices.Contains(dependencyManifests, manifest) {
+			return "", nil, nil, false
+		}
+		packages, ok := addedDependencies(manifest, writes)
+		if !ok {
+			return "", nil, nil, false
+		}
+		return "Dependency manifest " + manifest + " changed", packages,
+			map[string]string{"file": path, "tool": tc.Name, "packages": strings.Join(packages, " ")}, true
+	}
+
+	input, cmds, ok := bashCommands(tc)
+	if !ok {
+		return "", nil, nil, false
+	}
+	for _, cmd := range cmds {
+		if packages, ok := installedPackages(cmd); ok {
+			return "Dependencies installed", packages,
+				map[string]string{
+					"command":  truncate(input.Command, 100),
+					"packages": strings.Join(packages, " "),
+				}, true
+		}
+	}
+	return "", nil, nil, false
+}
+
+// dependencyLinePatterns match manifest lines that start a dependency
+// section or declare a dependency, capturing the package name.
+var dependencyLinePatterns = map[string]*regexp.Regexp{
+	"package.json":     regexp.MustCompile(`^\s*"(?:dependencies|devDependencies|peerDependencies|optionalDependencies)"\s*:|^\s*"([^"]+)"\s*:\s*"(?:[\^~<>=*]|\d|latest|workspace:|npm:|file:|link:|git|github:|https?:)`),
+	"go.mod":           regexp.MustCompile(`^\s*require\s*\(?\s*$|^\s*(?:require\s+)?([^\s/]+\.[^\s]+)\s+v\d`),
+	"Cargo.toml":       regexp.MustCompile(`^\s*\[(?:.*\.)?(?:dev-|build-)?dependencies(?:\..*)?\]|^\s*([\w-]+)\s*=\s*(?:\{|"[\^~=<>*]?\d)`),
+	"requirements.txt": regexp.MustCompile(`^\s*([A-Za-z0-9][\w.\[\]-]*)`),
+}
+
+// manifestMetadataKeys are manifest keys that look like dependencies but
+// describe the package itself or its toolchain.
+var manifestMetadataKeys = []string{"version", "edition", "rust-version", "node", "npm", "pnpm", "yarn"}
+
+// addedDependencies returns the dependencies that writes add to a
+// manifest, and whether any added line touches a dependency section.
+// Lines an edit already contained are not counted, so edits to scripts,
+// the version, or the module path are ignored.
+func addedDependencies(manifest string, writes []write) ([]string, bool) {
+	pattern := dependencyLinePatterns[manifest]
+	var packages []string
+	touched := false
+	for _, w := range writes {
+		previous := make(map[string]bool)
+		for line := range strings.Lines(w.previous) {
+			previous[strings.TrimSpace(line)] = true
+		}
+		for line := range strings.Lines(w.content) {
+			if previous[strings.TrimSpace(line)] || strings.HasPrefix(strings.TrimSpace(line), "#") {
+				continue
+			}
+			m := pattern.FindStringSubmatch(line)
+			if m == nil || slices.Contains(manifestMetadataKeys, m[1]) {
+				continue
+			}
+			touched = true
+			if m[1] != "" {
+				packages = append(packages, m[1])
+			}
+		}
+	}
+	return packages, touched
+}
+
+// unrequested returns the packages the approval request doesn't name.
+func unrequested(request string, packages []string) []string {
+	request = strings.ToLower(request)
+	var missing []string
+	for _, pkg := range packages {
+		name := strings.ToLower(packageName(pkg))
+		if !strings.Contains(request, name) && !strings.Contains(request, path.Base(name)) {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing
+}
+
+// packageName strips the version or extras from a package argument, as
+// in "axios@1.6", "requests==2.31", or "uvicorn[standard]".
+func packageName(pkg string) string {
+	if i := strings.LastIndex(pkg, "@"); i > 0 {
+		pkg = pkg[:i]
+	}
+	if i := strings.IndexAny(pkg, "=<>!~[;"); i > 0 {
+		pkg = pkg[:i]
+	}
+	return pkg
+}
+
+// packageManagers maps a package manager to the subcommands that add
+// named packages and its options that take a separate value.
+var packageManagers = map[string]struct {
+	subcommands []string
+	valued      []string
+}{
+	"npm":     {[]string{"install", "i", "add"}, []string{"--prefix", "-w", "--workspace"}},
+	"pnpm":    {[]string{"add", "install", "i"}, []string{"--filter", "-F", "-C", "--dir"}},
+	"yarn":    {[]string{"add"}, []string{"--cwd"}},
+	"pip":     {[]string{"install"}, []string{"-r", "--requirement", "-c", "--constraint", "-i", "--index-url", "--target", "-t"}},
+	"poetry":  {[]string{"add"}, []string{"-G", "--group", "-E", "--extras"}},
+	"go":      {[]string{"get"}, nil},
+	"cargo":   {[]string{"add"}, []string{"-p", "--package", "-F", "--features"}},
+	"brew":    {[]string{"install"}, nil},
+	"apt":     {[]string{"install"}, []string{"-o", "-t"}},
+	"apt-get": {[]string{"install"}, []string{"-o", "-t"}},
+}
+
+// installedPackages returns the packages a command installs. Commands
+// that only install what a manifest or lockfile already lists, such as a
+// bare `npm install` or `pip install -r requirements.txt`, are ignored.
+func installedPackages(cmd SimpleCommand) ([]string, bool) {
+	argv := cmd.Argv()
+	if len(argv) < 2 {
+		return nil, false
+	}
+	program := filepath.Base(argv[0])
+
+	switch {
+	case program == "pip3":
+		program = "pip"
+	case strings.HasPrefix(program, "python") && len(argv) > 3 && argv[1] == "-m" && strings.HasPrefix(argv[2], "pip"):
+		// python -m pip install x
+		argv = argv[2:]
+		program = "pip"
+	case program == "uv":
+		// uv add x, uv pip install x
+		switch argv[1] {
+		case "add":
+			return packageArgs(argv[2:], nil)
+		case "pip":
+			argv = argv[1:]
+			program = "pip"
+		}
+	}
+
+	pm, ok := packageManagers[program]
+	if !ok || len(argv) < 2 || !slices.Contains(pm.subcommands, argv[1]) {
+		return nil, false
+	}
+	return packageArgs(argv[2:], pm.valued)
+}
+
+// packageArgs returns the positional arguments naming packages.
+func packageArgs(args, valued []string) ([]string, bool) {
+	var packages []string
+	for _, arg := range positionalArgs(args, valued...) {
+		// Local paths install the project itself, not a new dependency
+		if arg == "." || strings.HasPrefix(arg, "./") {
+			continue
+		}
+		packages = append(packages, arg)
+	}
+	return packages, len(packages) > 0
+}
@@ -0,0 +1,165 @@
+package checker
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func TestDependencyApproval_ID(t *testing.T) {
+	c := &DependencyApproval{}
+	if c.ID() != "dependency-approval" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "dependency-approval")
+	}
+}
+
+func TestInstalledPackages(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"npm install lodash", []string{"lodash"}},
+		{"npm i -D typescript @types/node", []string{"typescript", "@types/node"}},
+		{"pnpm add zod", []string{"zod"}},
+		{"yarn add react", []string{"react"}},
+		{"pip install requests==2.31", []string{"requests==2.31"}},
+		{"python3 -m pip install -U httpx", []string{"httpx"}},
+		{"uv add fastapi", []string{"fastapi"}},
+		{"uv pip install rich", []string{"rich"}},
+		{"poetry add --group dev pytest", []string{"pytest"}},
+		{"go get github.com/spf13/cobra@latest", []string{"github.com/spf13/cobra@latest"}},
+		{"cargo add serde --features derive", []string{"serde"}},
+		{"brew install jq", []string{"jq"}},
+		{"sudo apt-get install -y curl", []string{"curl"}},
+		{"npm install", nil},
+		{"npm ci", nil},
+		{"pip install -r requirements.txt", nil},
+		{"pip install -e .", nil},
+		{"go get ./...", nil},
+		{"go build ./...", nil},
+		{"uv pip", nil},
+	}
+
+	for _, tt := range tests {
+		cmds := ParseShell(tt.command)
+		got, ok := installedPackages(cmds[0])
+		if ok != (tt.want != nil) || !slices.Equal(got, tt.want) {
+			t.Errorf("installedPackages(%q) = %v, %v; want %v", tt.command, got, ok, tt.want)
+		}
+	}
+}
+
+func TestDependencyApproval_Check(t *testing.T) {
+	request := say("Dependencies needed for the API client: axios. Approve installation? [Yes/No]")
+
+	tests := []struct {
+		name  string
+		turns []any
+		want  int
+	}{
+		{"no request", []any{bash("npm install axios")}, 1},
+		{"request without reply", []any{request, bash("npm install axios")}, 1},
+		{"request approved", []any{request, userText("", "Yes"), bash("npm install axios")}, 0},
+		{"request refused", []any{request, userText("", "No, use fetch"), bash("npm install axios")}, 1},
+		{"reply after install", []any{request, bash("npm install axios"), userText("", "yes")}, 1},
+		{"lockfile install", []any{bash("npm ci && npm install")}, 0},
+	}
+
+	c := &DependencyApproval{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := c.Check(conversation(tt.turns...))
+			if len(violations) != tt.want {
+				t.Errorf("got %d violations, want %d: %+v", len(violations), tt.want, violations)
+			}
+		})
+	}
+}
+
+func TestDependencyApproval_ManifestEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		tool  string
+		input map[string]string
+		want  int
+	}{
+		{"package.json dependency", "Edit", map[string]string{
+			"file_path":  "/repo/package.json",
+			"old_string": `"dependencies": {`,
+			"new_string": "\"dependencies\": {\n    \"axios\": \"^1.6.0\",",
+		}, 1},
+		{"package.json script", "Edit", map[string]string{
+			"file_path":  "/repo/package.json",
+			"old_string": `"build": "tsc"`,
+			"new_string": `"build": "tsc -p ."`,
+		}, 0},
+		{"package.json version", "Edit", map[string]string{
+			"file_path":  "/repo/package.json",
+			"old_string": `"version": "1.0.0"`,
+			"new_string": `"version": "1.1.0"`,
+		}, 0},
+		{"go.mod require", "Write", map[string]string{
+			"file_path": "/repo/go.mod",
+			"content":   "module example.com/app\n\ngo 1.25\n\nrequire github.com/spf13/cobra v1.8.0\n",
+		}, 1},
+		{"go.mod module path", "Edit", map[string]string{
+			"file_path":  "/repo/go.mod",
+			"old_string": "module example.com/app",
+			"new_string": "module example.com/service",
+		}, 0},
+		{"requirements.txt", "Edit", map[string]string{
+			"file_path":  "/repo/requirements.txt",
+			"old_string": "flask==3.0\n",
+			"new_string": "flask==3.0\nrequests>=2.31\n",
+		}, 1},
+		{"requirements.txt comment", "Edit", map[string]string{
+			"file_path":  "/repo/requirements.txt",
+			"old_string": "flask==3.0\n",
+			"new_string": "# web framework\nflask==3.0\n",
+		}, 0},
+		{"Cargo.toml dependency", "Edit", map[string]string{
+			"file_path":  "/repo/crates/core/Cargo.toml",
+			"old_string": "[dependencies]\n",
+			"new_string": "[dependencies]\nserde = { version = \"1\", features = [\"derive\"] }\n",
+		}, 1},
+		{"Cargo.toml package version", "Edit", map[string]string{
+			"file_path":  "/repo/Cargo.toml",
+			"old_string": `version = "0.1.0"`,
+			"new_string": `version = "0.2.0"`,
+		}, 0},
+		{"other file", "Edit", map[string]string{"file_path": "/repo/main.go", "new_string": `"axios": "^1.6.0"`}, 0},
+		{"read", "Read", map[string]string{"file_path": "/repo/package.json"}, 0},
+	}
+
+	c := &DependencyApproval{}
+	for _, tt := range tests {
+		input, _ := json.Marshal(tt.input)
+		tr := &transcript.Transcript{
+			ToolCalls: []transcript.ToolCall{{ID: "t1", Name: tt.tool, Input: input}},
+		}
+		if got := len(c.Check(tr)); got != tt.want {
+			t.Errorf("%s: got %d violations, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDependencyApproval_ApprovalCoversNamedPackages(t *testing.T) {
+	tr := conversation(
+		say("Dependencies needed for the API client: axios. Approve installation? [Yes/No]"),
+		userText("", "yes"),
+		bash("npm install axios@1.6"),
+		bash("npm install left-pad"),
+	)
+
+	c := &DependencyApproval{}
+	violations := c.Check(tr)
+	if len(violations) != 1 || violations[0].ToolCallID != "t2" {
+		t.Fatalf("expected 1 violation for the unnamed package, got %+v", violations)
+	}
+	want := "Dependencies installed without approval for left-pad, which the approval request didn't name"
+	if violations[0].Message != want {
+		t.Errorf("Message = %q, want %q", violations[0].Message, want)
+	}
+}
@@ -1,9 +1,13 @@
 package checker
 
 import (
-	"encoding/json"
+	"fmt"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
 )
@@ -15,7 +19,11 @@ func init() {
 // ExponentialBackoff checks that monitoring loops use exponential backoff.
 // Per AGENTS.md Rule 7: "Use exponential backoff when monitoring processes
 // (5s → 10s → 20s → 40s → 60s cap)."
-type ExponentialBackoff struct{}
+type ExponentialBackoff struct {
+	// MonitoringCommands are command prefixes treated as polling.
+	// Defaults to monitoringCommands if nil.
+	MonitoringCommands []string
+}
 
 func (c *ExponentialBackoff) ID() string {
 	return "exponential-backoff"
@@ -25,24 +33,97 @@ func (c *ExponentialBackoff) Description() string {
 	return "Ensures monitoring loops use exponential backoff (Rule 7)"
 }
 
-// sleepPattern matches sleep commands with numeric seconds (anywhere in command)
-var sleepPattern = regexp.MustCompile(`\bsleep\s+(\d+)`)
+// backoffHint is the Rule 7 backoff sequence, for violation messages.
+const backoffHint = "(5s → 10s → 20s → 40s → 60s cap)"
+
+// sleepUnits are the suffixes accepted by sleep.
+var sleepUnits = map[string]time.Duration{
+	"":  time.Second,
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// sleepArgPattern matches a sleep duration such as 5, 0.5, or 2m.
+var sleepArgPattern = regexp.MustCompile(`^(\d+(?:\.\d*)?|\.\d+)([smhd]?)$`)
+
+// parseSleep parses sleep arguments such as "5", "0.5", or "1m 30s", which
+// sleep adds together.
+func parseSleep(args []string) (time.Duration, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, arg := range args {
+		m := sleepArgPattern.FindStringSubmatch(arg)
+		if m == nil {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(n * float64(sleepUnits[m[2]]))
+	}
+	return total, true
+}
+
+// sleepDuration returns the total time slept by the commands outside
+// loops (standalone or embedded like "echo x && sleep 5").
+func sleepDuration(cmds []SimpleCommand) (time.Duration, bool) {
+	var total time.Duration
+	found := false
+	for _, cmd := range cmds {
+		argv := cmd.Argv()
+		if cmd.Program() != "sleep" || cmd.Loop != nil {
+			continue
+		}
+		if d, ok := parseSleep(argv[1:]); ok {
+			total += d
+			found = true
+		}
+	}
+	return total, found
+}
+
+// Configure sets options from the project configuration file.
+func (c *ExponentialBackoff) Configure(decode func(any) error) error {
+	var opts struct {
+		MonitoringCommands []string `yaml:"monitoring_commands"`
+	}
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	if opts.MonitoringCommands != nil {
+		c.MonitoringCommands = opts.MonitoringCommands
+	}
+	return nil
+}
 
 // monitoringCommands are commands typically used in monitoring loops
-var monitoringCommands = map[string]bool{
-	"kubectl get":    true,
-	"kubectl describe": true,
-	"docker ps":      true,
-	"docker logs":    true,
-	"git status":     true,
-	"ps aux":         true,
-	"tail -f":        true,
+var monitoringCommands = []string{
+	"kubectl get",
+	"kubectl describe",
+	"docker ps",
+	"docker logs",
+	"git status",
+	"ps aux",
+	"tail -f",
+	"gh pr checks",
+	"gh run view",
+	"curl",
 }
 
 // isMonitoringCommand checks if a command looks like a monitoring/polling command
-func isMonitoringCommand(cmd string) bool {
-	for prefix := range monitoringCommands {
-		if len(cmd) >= len(prefix) && cmd[:len(prefix)] == prefix {
+func (c *ExponentialBackoff) isMonitoringCommand(cmd SimpleCommand) bool {
+	prefixes := c.MonitoringCommands
+	if prefixes == nil {
+		prefixes = monitoringCommands
+	}
+	line := strings.Join(cmd.Argv(), " ")
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(line, prefix) {
 			return true
 		}
 	}
@@ -59,26 +140,20 @@ func (c *ExponentialBackoff) Check(t *transcript.Transcript) []Violation {
 
 	// Track sleep durations in sequence with their associated commands
 	type sleepInfo struct {
-		duration  int
-		toolCall  transcript.ToolCall
-		prevCmd   string // The command before this sleep
+		duration time.Duration
+		toolCall transcript.ToolCall
+		prevCmd  string // The command before this sleep
 	}
 	var sleeps []sleepInfo
 	var lastCommand string
-	var lastCommandWasPolling bool
 
 	for _, tc := range t.ToolCalls {
-		if tc.Name == "Bash" {
-			var input struct {
-				Command string `json:"command"`
-			}
-			if err := json.Unmarshal(tc.Input, &input); err != nil {
-				continue
-			}
+		if input, cmds, ok := bashCommands(tc); ok {
+			// Loops within the command are checked on their own
+			violations = append(violations, c.checkCommand(tc, scriptCommands(cmds))...)
 
 			// Check for sleep command (can be standalone or embedded like "echo x && sleep 5")
-			if matches := sleepPattern.FindStringSubmatch(input.Command); matches != nil {
-				duration, _ := strconv.Atoi(matches[1])
+			if duration, ok := sleepDuration(cmds); ok {
 				// For embedded sleep, use the full command as prevCmd
 				prevCmd := lastCommand
 				if prevCmd == "" {
@@ -96,80 +171,212 @@ func (c *ExponentialBackoff) Check(t *transcript.Transcript) []Violation {
 
 			// Track the command
 			lastCommand = input.Command
-			lastCommandWasPolling = isMonitoringCommand(input.Command)
 		} else if isBashOutputCheck(tc) {
 			// BashOutput is also a polling pattern
 			lastCommand = "BashOutput"
-			lastCommandWasPolling = true
 		}
 	}
 
-	// Analyze sleep pattern: need 3+ sleeps to establish a monitoring pattern
-	if len(sleeps) >= 3 {
-		// Check if the sleeps are between repeated commands (actual monitoring)
-		// All sleeps should follow the same command pattern
-		isMonitoringLoop := true
-		firstPrevCmd := sleeps[0].prevCmd
-		for _, s := range sleeps {
-			if s.prevCmd != firstPrevCmd {
-				isMonitoringLoop = false
-				break
-			}
-		}
+	// Analyze sleep pattern across tool calls: need 3+ sleeps to establish
+	// a monitoring pattern
+	if len(sleeps) < 3 {
+		return violations
+	}
 
-		// If commands vary, it's not a monitoring loop
-		if !isMonitoringLoop {
+	// Check if the sleeps are between repeated commands (actual monitoring)
+	// All sleeps should follow the same command pattern
+	firstPrevCmd := sleeps[0].prevCmd
+	for _, s := range sleeps {
+		if s.prevCmd != firstPrevCmd {
+			// If commands vary, it's not a monitoring loop
 			return violations
 		}
+	}
 
-		// Check if sleeps are constant (not exponential)
-		isConstant := true
-		for i := 1; i < len(sleeps); i++ {
-			if sleeps[i].duration != sleeps[0].duration {
-				isConstant = false
-				break
-			}
+	// Check if sleeps are constant (not exponential)
+	isConstant := true
+	for i := 1; i < len(sleeps); i++ {
+		if sleeps[i].duration != sleeps[0].duration {
+			isConstant = false
+			break
 		}
+	}
 
-		if isConstant {
-			// Report violation on the third constant sleep
+	if isConstant {
+		// Report violation on the third constant sleep
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 7",
+			Severity:   SeverityWarning,
+			Message:    "Monitoring loop detected with constant sleep; use exponential backoff " + backoffHint,
+			EventUUID:  sleeps[2].toolCall.EventUUID,
+			ToolCallID: sleeps[2].toolCall.ID,
+		})
+		return violations
+	}
+
+	// Check if it's proper exponential backoff
+	// Allow: each sleep should be >= previous (with cap at 60)
+	for i := 1; i < len(sleeps); i++ {
+		prev := sleeps[i-1].duration
+		curr := sleeps[i].duration
+
+		// Allow staying at 60s cap
+		if prev >= backoffCap && curr >= backoffCap {
+			continue
+		}
+
+		// Current should be >= previous for backoff
+		if curr < prev {
 			violations = append(violations, Violation{
 				CheckerID:  c.ID(),
 				Rule:       "Rule 7",
 				Severity:   SeverityWarning,
-				Message:    "Monitoring loop detected with constant sleep; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)",
-				EventUUID:  sleeps[2].toolCall.EventUUID,
-				ToolCallID: sleeps[2].toolCall.ID,
+				Message:    "Sleep duration decreased; exponential backoff should increase " + backoffHint,
+				EventUUID:  sleeps[i].toolCall.EventUUID,
+				ToolCallID: sleeps[i].toolCall.ID,
 			})
-		} else {
-			// Check if it's proper exponential backoff
-			// Allow: each sleep should be >= previous (with cap at 60)
-			for i := 1; i < len(sleeps); i++ {
-				prev := sleeps[i-1].duration
-				curr := sleeps[i].duration
-
-				// Allow staying at 60s cap
-				if prev >= 60 && curr >= 60 {
-					continue
-				}
+			break
+		}
+	}
 
-				// Current should be >= previous for backoff
-				if curr < prev {
-					violations = append(violations, Violation{
-						CheckerID:  c.ID(),
-						Rule:       "Rule 7",
-						Severity:   SeverityWarning,
-						Message:    "Sleep duration decreased; exponential backoff should increase (5s → 10s → 20s → 40s → 60s cap)",
-						EventUUID:  sleeps[i].toolCall.EventUUID,
-						ToolCallID: sleeps[i].toolCall.ID,
-					})
-					break
+	return violations
+}
+
+// checkCommand checks the polling loops and watch commands within a
+// single Bash call.
+func (c *ExponentialBackoff) checkCommand(tc transcript.ToolCall, cmds []SimpleCommand) []Violation {
+	var violations []Violation
+
+	report := func(message, source string) {
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 7",
+			Severity:   SeverityWarning,
+			Message:    message,
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Context: map[string]string{
+				"command": truncate(source, 100),
+			},
+		})
+	}
+
+	checked := make(map[*Loop]bool)
+	for _, cmd := range cmds {
+		switch cmd.Program() {
+		case "sleep":
+			loop := cmd.Loop
+			if loop == nil || checked[loop] || !c.isPolling(loop, cmds) {
+				continue
+			}
+			checked[loop] = true
+			if message, ok := loopBackoffProblem(loop, cmd); ok {
+				report(message, loop.Source)
+			}
+
+		case "watch":
+			// watch reruns a command at a fixed interval (default 2s)
+			interval := 2 * time.Second
+			args := cmd.Argv()[1:]
+			if v, ok := flagValue(args, "-n", "--interval"); ok {
+				d, ok := parseSleep([]string{v})
+				if !ok {
+					continue
 				}
+				interval = d
+			}
+			if interval < backoffCap {
+				report(fmt.Sprintf("watch polls at a constant %s interval; use exponential backoff %s", interval, backoffHint), cmd.Source)
 			}
 		}
 	}
 
-	_ = lastCommandWasPolling // May use later for enhanced detection
-
 	return violations
 }
+
+// isPolling reports whether a loop repeats a check until something
+// changes: a while or until loop (other than one reading input), or a
+// for loop that runs a monitoring command or breaks out early.
+func (c *ExponentialBackoff) isPolling(loop *Loop, cmds []SimpleCommand) bool {
+	if loop.Kind != "for" {
+		return !slices.ContainsFunc(loop.Cond, func(cmd SimpleCommand) bool { return cmd.Program() == "read" })
+	}
+	for _, cmd := range cmds {
+		if cmd.Loop != loop {
+			continue
+		}
+		switch cmd.Program() {
+		case "break", "exit", "return":
+			return true
+		}
+		if c.isMonitoringCommand(cmd) {
+			return true
+		}
+	}
+	return false
+}
+
+// sleepVariablePattern matches a sleep argument that is a variable.
+var sleepVariablePattern = regexp.MustCompile(`^\$\{?(\w+)\}?$`)
+
+// loopBackoffProblem describes why a loop's sleep isn't exponential
+// backoff. A variable delay must be multiplied each iteration and capped,
+// as in the Rule 7 example.
+func loopBackoffProblem(loop *Loop, sleep SimpleCommand) (string, bool) {
+	words := sleep.Words[len(sleep.Words)-len(sleep.Argv())+1:]
+	args := sleep.Argv()[1:]
+
+	if d, ok := parseSleep(args); ok {
+		return fmt.Sprintf("Monitoring loop sleeps a constant %s; use exponential backoff %s", d, backoffHint), true
+	}
+	if len(words) != 1 {
+		return "", false
+	}
+	m := sleepVariablePattern.FindStringSubmatch(words[0].Raw)
+	if m == nil {
+		return "", false
+	}
+
+	name := regexp.QuoteMeta(m[1])
+	ref := `\$?\{?` + name + `\b\}?`
+	grows := regexp.MustCompile(`\b` + name + `\s*(\*|<<)=` +
+		`|\b` + name + `=\$\(\(\s*` + ref + `\s*(\*|<<)` +
+		`|\b` + name + `=\$\(\(\s*\d+\s*\*\s*` + ref)
+	capped := regexp.MustCompile(ref + `\s*(\*\s*\d+\s*)?(-gt|-ge|>)` + `|(-lt|-le|<)=?\s*` + ref)
+
+	switch {
+	case !grows.MatchString(loop.Source):
+		return fmt.Sprintf("Monitoring loop delay $%s doesn't grow exponentially; double it each iteration %s", m[1], backoffHint), true
+	case !capped.MatchString(loop.Source):
+		return fmt.Sprintf("Monitoring loop delay $%s has no cap; cap it at 60s %s", m[1], backoffHint), true
+	}
+	return "", false
+}
+
+// shellPrograms are programs that run a script given with -c.
+var shellPrograms = []string{"bash", "sh", "zsh", "dash"}
+
+// scriptCommands returns cmds with the commands of any inline scripts run
+// with `bash -c` appended, so that loops inside them are checked.
+func scriptCommands(cmds []SimpleCommand) []SimpleCommand {
+	all := cmds
+	for _, cmd := range cmds {
+		argv := cmd.Argv()
+		if len(argv) < 3 || !slices.Contains(shellPrograms, filepath.Base(argv[0])) {
+			continue
+		}
+		// The script follows the options, which may combine -c with
+		// others as in `bash -lc`.
+		for i, arg := range argv[1 : len(argv)-1] {
+			if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+				break
+			}
+			if strings.Contains(arg, "c") {
+				all = append(all, scriptCommands(ParseShell(argv[i+2]))...)
+				break
+			}
+		}
+	}
+	return all
+}
@@ -2,7 +2,9 @@ package checker
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
 )
@@ -239,3 +241,140 @@ func TestExponentialBackoff_Registered(t *testing.T) {
 		t.Error("exponential-backoff checker not registered")
 	}
 }
+
+func TestParseSleep(t *testing.T) {
+	tests := []struct {
+		args []string
+		want time.Duration
+		ok   bool
+	}{
+		{[]string{"5"}, 5 * time.Second, true},
+		{[]string{"0.5"}, 500 * time.Millisecond, true},
+		{[]string{"5m"}, 5 * time.Minute, true},
+		{[]string{"1m", "30s"}, 90 * time.Second, true},
+		{[]string{"1h"}, time.Hour, true},
+		{[]string{"$delay"}, 0, false},
+		{[]string{"infinity"}, 0, false},
+		{nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseSleep(tt.args)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseSleep(%q) = %v, %v; want %v, %v", tt.args, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExponentialBackoff_Loops(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string // violation messages
+	}{
+		{
+			name:    "constant sleep in until loop",
+			command: "while ! gh pr checks; do sleep 5; done",
+			want:    []string{"Monitoring loop sleeps a constant 5s; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "constant sleep in minutes",
+			command: "until kubectl rollout status deploy/web; do sleep 0.5m; done",
+			want:    []string{"Monitoring loop sleeps a constant 30s; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name: "Rule 7 example",
+			command: `delay=5
+max_delay=60
+while true; do
+  gh pr checks --watch 2>/dev/null && break
+  echo "Checks still running, waiting ${delay}s..."
+  sleep $delay
+  delay=$((delay * 2))
+  [[ $delay -gt $max_delay ]] && delay=$max_delay
+done`,
+		},
+		{
+			name:    "arithmetic doubling",
+			command: "d=5; while ! curl -sf localhost:8080; do sleep $d; (( d *= 2 )); (( d > 60 )) && d=60; done",
+		},
+		{
+			name:    "uncapped doubling",
+			command: "d=5; while ! curl -sf localhost:8080; do sleep $d; d=$((d * 2)); done",
+			want:    []string{"Monitoring loop delay $d has no cap; cap it at 60s (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "linear growth",
+			command: "d=5; while ! curl -sf localhost:8080; do sleep $d; d=$((d + 5)); done",
+			want:    []string{"Monitoring loop delay $d doesn't grow exponentially; double it each iteration (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "polling for loop",
+			command: "for i in 1 2 3 4 5; do gh pr checks && break; sleep 10; done",
+			want:    []string{"Monitoring loop sleeps a constant 10s; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "rate-limited batch",
+			command: "for f in *.json; do upload $f; sleep 1; done",
+		},
+		{
+			name:    "reading input",
+			command: "while read -r line; do echo $line; sleep 1; done < urls.txt",
+		},
+		{
+			name:    "inside timeout",
+			command: `timeout 5m bash -c 'while ! gh pr checks; do sleep 15; done'`,
+			want:    []string{"Monitoring loop sleeps a constant 15s; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "watch interval",
+			command: "watch -n 5 kubectl get pods",
+			want:    []string{"watch polls at a constant 5s interval; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "watch default interval",
+			command: "watch gh pr checks",
+			want:    []string{"watch polls at a constant 2s interval; use exponential backoff (5s → 10s → 20s → 40s → 60s cap)"},
+		},
+		{
+			name:    "slow watch",
+			command: "watch --interval=60 gh pr checks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ExponentialBackoff{}
+			tr := &transcript.Transcript{ToolCalls: []transcript.ToolCall{bashCall("t1", "e1", tt.command)}}
+			var got []string
+			for _, v := range c.Check(tr) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_SleepUnitsAcrossCalls(t *testing.T) {
+	// 30s → 1m → 0.5m is a decrease, even though the numbers grow
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			bashCall("t1", "e1", "kubectl get pods"),
+			bashCall("t2", "e2", "sleep 30"),
+			bashCall("t3", "e3", "kubectl get pods"),
+			bashCall("t4", "e4", "sleep 1m"),
+			bashCall("t5", "e5", "kubectl get pods"),
+			bashCall("t6", "e6", "sleep 0.5m"),
+			bashCall("t7", "e7", "kubectl get pods"),
+		},
+	}
+
+	c := &ExponentialBackoff{}
+	violations := c.Check(tr)
+
+	if len(violations) != 1 || violations[0].ToolCallID != "t6" {
+		t.Errorf("expected a decrease violation on t6, got %v", violations)
+	}
+}
@@ -1,9 +1,6 @@
 package checker
 
 import (
-	"encoding/json"
-	"regexp"
-
 	"github.com/michaellady/agents-lint/internal/transcript"
 )
 
@@ -20,63 +17,81 @@ func (c *GitBranch) ID() string {
 }
 
 func (c *GitBranch) Description() string {
-	return "Ensures proper git branch workflow (Rule 3: no direct commits to main)"
+	return "Ensures proper git branch workflow (Rule 3: no direct commits or pushes to main)"
 }
 
-// Patterns for detecting branch violations
-var (
-	// Direct push to main/master
-	pushMainPattern = regexp.MustCompile(`git\s+push\s+(-[^\s]+\s+)*origin\s+(main|master)\b`)
-	// Push with -f to main/master
-	forcePushMainPattern = regexp.MustCompile(`git\s+push\s+.*(-f|--force).*origin\s+(main|master)\b`)
-	// Checkout main/master with intent to commit
-	checkoutMainPattern = regexp.MustCompile(`git\s+checkout\s+(main|master)\s*$`)
-)
+// protectedBranches are branches that must only change through PRs
+var protectedBranches = map[string]bool{
+	"main":   true,
+	"master": true,
+}
 
 func (c *GitBranch) Check(t *transcript.Transcript) []Violation {
 	var violations []Violation
 
-	for _, tc := range t.ToolCalls {
-		if tc.Name != "Bash" {
+	reported := make(map[string]bool)
+	for _, op := range GitOps(t) {
+		if reported[op.ToolCall.ID] {
 			continue
 		}
 
-		var input BashInput
-		if err := json.Unmarshal(tc.Input, &input); err != nil {
-			continue
+		var message string
+		context := map[string]string{
+			"command": truncate(op.Command.Source, 100),
 		}
+		if op.Branch != "" {
+			context["branch"] = op.Branch
+		}
+
+		switch op.Kind {
+		case GitOpPush:
+			ref, ok := protectedRef(op.Refs)
+			if !ok {
+				continue
+			}
+			context["target"] = op.Remote + "/" + ref.Dst
+
+			// Check for force push to main (most severe)
+			switch {
+			case ref.Delete:
+				message = "Deletion of main/master branch on remote detected; this is extremely dangerous"
+			case op.Force || ref.Force:
+				message = "Force push to main/master branch detected; this is extremely dangerous"
+			default:
+				message = "Direct push to main/master branch; use feature branch + PR instead"
+			}
 
-		// Check for force push to main (most severe)
-		if forcePushMainPattern.MatchString(input.Command) {
-			violations = append(violations, Violation{
-				CheckerID:  c.ID(),
-				Rule:       "Rule 3",
-				Severity:   SeverityError,
-				Message:    "Force push to main/master branch detected; this is extremely dangerous",
-				EventUUID:  tc.EventUUID,
-				ToolCallID: tc.ID,
-				Context: map[string]string{
-					"command": truncate(input.Command, 100),
-				},
-			})
+		case GitOpCommit:
+			if !protectedBranches[op.Branch] {
+				continue
+			}
+			message = "Commit on " + op.Branch + " branch; create a feature branch first"
+
+		default:
 			continue
 		}
 
-		// Check for direct push to main
-		if pushMainPattern.MatchString(input.Command) {
-			violations = append(violations, Violation{
-				CheckerID:  c.ID(),
-				Rule:       "Rule 3",
-				Severity:   SeverityError,
-				Message:    "Direct push to main/master branch; use feature branch + PR instead",
-				EventUUID:  tc.EventUUID,
-				ToolCallID: tc.ID,
-				Context: map[string]string{
-					"command": truncate(input.Command, 100),
-				},
-			})
-		}
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 3",
+			Severity:   SeverityError,
+			Message:    message,
+			EventUUID:  op.ToolCall.EventUUID,
+			ToolCallID: op.ToolCall.ID,
+			Context:    context,
+		})
+		reported[op.ToolCall.ID] = true
 	}
 
 	return violations
 }
+
+// protectedRef returns the first ref update that targets a protected branch.
+func protectedRef(refs []RefUpdate) (RefUpdate, bool) {
+	for _, ref := range refs {
+		if protectedBranches[ref.Dst] {
+			return ref, true
+		}
+	}
+	return RefUpdate{}, false
+}
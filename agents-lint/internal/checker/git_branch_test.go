@@ -2,6 +2,7 @@ package checker
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
@@ -146,3 +147,105 @@ func TestGitBranch_Registered(t *testing.T) {
 		t.Error("git-branch checker not registered")
 	}
 }
+
+func TestGitBranch_ArgumentAware(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		violations int
+	}{
+		{"chained push", "cd repo && git push origin main", 1},
+		{"other remote", "git push upstream master", 1},
+		{"explicit refspec", "git push origin HEAD:main", 1},
+		{"forced refspec", "git push origin +main", 1},
+		{"global options", "git -C ../repo push origin main", 1},
+		{"quoted in echo", `echo "never git push origin main"`, 0},
+		{"variable branch", `git push origin "$BRANCH"`, 0},
+		{"main as source only", "git push origin main:feature-1", 0},
+	}
+
+	c := &GitBranch{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := json.Marshal(BashInput{Command: tt.command})
+			tr := &transcript.Transcript{
+				ToolCalls: []transcript.ToolCall{
+					{ID: "t1", Name: "Bash", Input: input},
+				},
+			}
+
+			violations := c.Check(tr)
+			if len(violations) != tt.violations {
+				t.Errorf("expected %d violations for %q, got %d", tt.violations, tt.command, len(violations))
+			}
+		})
+	}
+}
+
+func TestGitBranch_ForcedRefspecMessage(t *testing.T) {
+	input, _ := json.Marshal(BashInput{Command: "git push origin +main"})
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", Name: "Bash", Input: input},
+		},
+	}
+
+	violations := (&GitBranch{}).Check(tr)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, "Force push") {
+		t.Errorf("expected force push violation, got %v", violations)
+	}
+}
+
+func TestGitBranch_TracksCurrentBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		commands   []string
+		violations int
+	}{
+		{"push HEAD while on main", []string{"git checkout main", "git push origin HEAD"}, 1},
+		{"bare push while on master", []string{"git switch master && git pull", "git push"}, 1},
+		{"push HEAD from feature", []string{"git checkout main", "git checkout -b AGENTS-9", "git push -u origin HEAD"}, 0},
+		{"file checkout keeps branch", []string{"git checkout -b AGENTS-9", "git checkout main -- go.sum", "git push"}, 0},
+		{"lease to main", []string{"git push --force-with-lease origin main"}, 1},
+		{"delete main", []string{"git push origin :main"}, 1},
+	}
+
+	c := &GitBranch{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := c.Check(bashTranscript(tt.commands...))
+			if len(violations) != tt.violations {
+				t.Errorf("expected %d violations, got %d: %v", tt.violations, len(violations), violations)
+			}
+		})
+	}
+}
+
+func TestGitBranch_CommitOnMain(t *testing.T) {
+	tests := []struct {
+		name       string
+		commands   []string
+		violations int
+	}{
+		{"commit after checkout main", []string{"git checkout main", "git add . && git commit -m 'Fix'"}, 1},
+		{"commit on feature branch", []string{"git checkout main", "git checkout -b AGENTS-3", "git commit -m 'Fix'"}, 0},
+		{"commit in worktree", []string{"git checkout main", "git worktree add -b AGENTS-4 ../repo-AGENTS-4", "cd ../repo-AGENTS-4 && git commit -m 'Fix'"}, 0},
+		{"commit back in main checkout", []string{"git checkout main", "git worktree add -b AGENTS-4 ../repo-AGENTS-4", "git commit -m 'Fix'"}, 1},
+		{"unknown branch", []string{"git commit -m 'Fix'"}, 0},
+	}
+
+	c := &GitBranch{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := bashTranscript(tt.commands...)
+			tr.CWD = "/repo"
+			violations := c.Check(tr)
+			if len(violations) != tt.violations {
+				t.Errorf("expected %d violations, got %d: %v", tt.violations, len(violations), violations)
+			}
+		})
+	}
+}
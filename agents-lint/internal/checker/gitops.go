@@ -0,0 +1,317 @@
+package checker
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// GitOpKind identifies the kind of git operation a command performs.
+type GitOpKind int
+
+const (
+	// GitOpPush is `git push`.
+	GitOpPush GitOpKind = iota
+	// GitOpCommit is `git commit`.
+	GitOpCommit
+	// GitOpCheckout is `git checkout` or `git switch` of a branch.
+	GitOpCheckout
+	// GitOpMerge is `git merge`.
+	GitOpMerge
+	// GitOpBranchCreate is `git branch <name>`.
+	GitOpBranchCreate
+	// GitOpBranchDelete is `git branch -d/-D <name>`.
+	GitOpBranchDelete
+	// GitOpWorktreeAdd is `git worktree add`.
+	GitOpWorktreeAdd
+	// GitOpWorktreeRemove is `git worktree remove`.
+	GitOpWorktreeRemove
+)
+
+func (k GitOpKind) String() string {
+	switch k {
+	case GitOpPush:
+		return "push"
+	case GitOpCommit:
+		return "commit"
+	case GitOpCheckout:
+		return "checkout"
+	case GitOpMerge:
+		return "merge"
+	case GitOpBranchCreate:
+		return "branch-create"
+	case GitOpBranchDelete:
+		return "branch-delete"
+	case GitOpWorktreeAdd:
+		return "worktree-add"
+	case GitOpWorktreeRemove:
+		return "worktree-remove"
+	default:
+		return "unknown"
+	}
+}
+
+// GitOp is a git command interpreted in the context of the session.
+type GitOp struct {
+	Kind GitOpKind
+
+	// ToolCall is the Bash tool call that ran the command.
+	ToolCall transcript.ToolCall
+
+	// Command is the parsed git command.
+	Command SimpleCommand
+
+	// Branch is the branch checked out when the command ran ("" if unknown).
+	Branch string
+
+	// Dir is the directory the command ran in, including any `git -C`.
+	Dir string
+
+	// Remote is the push destination remote.
+	Remote string
+
+	// Refs are the branch updates a push makes, with HEAD and implicit
+	// refspecs resolved against Branch.
+	Refs []RefUpdate
+
+	// Force is true for forced pushes (--force, --force-with-lease, +refspec)
+	// and forced worktree removal.
+	Force bool
+
+	// Target is the branch checked out, merged, created, or deleted.
+	Target string
+
+	// Create is true when a checkout or worktree add also creates Target.
+	Create bool
+
+	// Path is the worktree directory for worktree operations, resolved
+	// against Dir.
+	Path string
+
+	// Amend is true for `git commit --amend`.
+	Amend bool
+}
+
+// RefUpdate is one branch a push updates.
+type RefUpdate struct {
+	Src    string // Local ref being pushed ("" for deletes)
+	Dst    string // Remote branch being updated ("" if unknown)
+	Force  bool   // Forced via a leading +
+	Delete bool   // Deletes Dst on the remote
+}
+
+// pushValueFlags are git push options that take a separate value
+var pushValueFlags = []string{"-o", "--push-option", "--repo", "--receive-pack", "--exec"}
+
+// GitOps interprets the git commands in every Bash tool call, in order,
+// tracking the checked-out branch through the session (see States). The
+// branch is learned from checkouts, from session log gitBranch fields, and
+// from the output of `git status` and `git branch --show-current`.
+func GitOps(t *transcript.Transcript) []GitOp {
+	var ops []GitOp
+	replaySession(t, func(_ transcript.ToolCall, _ State, callOps []GitOp) bool {
+		ops = append(ops, callOps...)
+		return true
+	})
+	return ops
+}
+
+// reportedBranch extracts the current branch from the output of a command
+// that prints it, such as `git status` or `git branch --show-current`.
+func reportedBranch(cmds []SimpleCommand, result string) string {
+	if len(cmds) != 1 || result == "" {
+		return ""
+	}
+	sub, args, ok := cmds[0].Subcommand("git")
+	if !ok {
+		return ""
+	}
+
+	pos := positionalArgs(args)
+	switch {
+	case sub == "status":
+		if rest, ok := strings.CutPrefix(result, "On branch "); ok {
+			line, _, _ := strings.Cut(rest, "\n")
+			return strings.TrimSpace(line)
+		}
+	case sub == "branch" && hasFlag(args, "--show-current"),
+		sub == "rev-parse" && hasFlag(args, "--abbrev-ref") && slices.Equal(pos, []string{"HEAD"}):
+		if b := strings.TrimSpace(result); b != "HEAD" && !strings.ContainsAny(b, " \n") {
+			return b
+		}
+	}
+	return ""
+}
+
+// parseGitOp interprets a single command as a git operation, given the
+// currently checked-out branch and the one before it (for `checkout -`).
+func parseGitOp(cmd SimpleCommand, branch, previous string) (GitOp, bool) {
+	sub, args, ok := cmd.Subcommand("git")
+	if !ok {
+		return GitOp{}, false
+	}
+	op := GitOp{Command: cmd, Branch: branch}
+
+	switch sub {
+	case "push":
+		op.Kind = GitOpPush
+		parsePush(&op, args)
+
+	case "commit":
+		op.Kind = GitOpCommit
+		op.Amend = hasFlag(args, "--amend")
+
+	case "checkout", "switch":
+		op.Kind = GitOpCheckout
+		if !parseCheckout(&op, sub, args, previous) {
+			return GitOp{}, false
+		}
+
+	case "merge":
+		if hasFlag(args, "--abort", "--continue", "--quit") {
+			return GitOp{}, false
+		}
+		op.Kind = GitOpMerge
+		if pos := positionalArgs(args, "-m", "-s", "-X", "--strategy", "--strategy-option", "-F", "--file"); len(pos) > 0 {
+			op.Target = pos[0]
+		}
+
+	case "branch":
+		pos := positionalArgs(args, "-u", "--set-upstream-to", "--contains", "--merged", "--no-merged", "--points-at", "--sort", "--format")
+		switch {
+		case hasFlag(args, "-d", "-D", "--delete"):
+			op.Kind = GitOpBranchDelete
+		case len(pos) > 0 && !hasFlag(args, "-m", "-M", "-c", "-C", "--move", "--copy", "-l", "--list", "-a", "-r", "--show-current"):
+			op.Kind = GitOpBranchCreate
+		default:
+			return GitOp{}, false
+		}
+		if len(pos) == 0 {
+			return GitOp{}, false
+		}
+		op.Target = pos[0]
+
+	case "worktree":
+		if len(args) == 0 {
+			return GitOp{}, false
+		}
+		pos := positionalArgs(args[1:], "-b", "-B", "--reason")
+		switch args[0] {
+		case "add":
+			op.Kind = GitOpWorktreeAdd
+			if len(pos) == 0 {
+				return GitOp{}, false
+			}
+			op.Path = pos[0]
+			if name, ok := flagValue(args[1:], "-b", "-B"); ok {
+				op.Target, op.Create = name, true
+			} else if len(pos) > 1 {
+				op.Target = pos[1]
+			} else if !hasFlag(args[1:], "--detach", "-d") {
+				// git creates a branch named after the directory
+				op.Target, op.Create = filepath.Base(op.Path), true
+			}
+		case "remove":
+			op.Kind = GitOpWorktreeRemove
+			if len(pos) == 0 {
+				return GitOp{}, false
+			}
+			op.Path = pos[0]
+			op.Force = hasFlag(args[1:], "-f", "--force")
+		default:
+			return GitOp{}, false
+		}
+
+	default:
+		return GitOp{}, false
+	}
+
+	return op, true
+}
+
+// parsePush resolves the remote and refspecs of a git push.
+func parsePush(op *GitOp, args []string) {
+	op.Force = hasFlag(args, "-f", "--force", "--force-with-lease", "--force-if-includes")
+	deleting := hasFlag(args, "-d", "--delete")
+
+	pos := positionalArgs(args, pushValueFlags...)
+	op.Remote = "origin"
+	if len(pos) > 0 {
+		op.Remote = pos[0]
+	}
+
+	if len(pos) < 2 {
+		// No refspec: push.default=simple pushes the current branch
+		if op.Branch != "" && !hasFlag(args, "--all", "--mirror", "--tags") {
+			op.Refs = []RefUpdate{{Src: op.Branch, Dst: op.Branch}}
+		}
+		return
+	}
+
+	for _, refspec := range pos[1:] {
+		var ref RefUpdate
+		if strings.HasPrefix(refspec, "+") {
+			ref.Force = true
+			refspec = refspec[1:]
+		}
+
+		src, dst, explicit := strings.Cut(refspec, ":")
+		if deleting || (explicit && src == "") {
+			op.Refs = append(op.Refs, RefUpdate{Dst: strings.TrimPrefix(src+dst, "refs/heads/"), Delete: true})
+			continue
+		}
+		if !explicit {
+			dst = src
+		}
+		if src == "HEAD" && op.Branch != "" {
+			src = op.Branch
+			if !explicit {
+				dst = op.Branch
+			}
+		} else if !explicit && src == "HEAD" {
+			// Pushes the current branch, which is unknown
+			dst = ""
+		}
+
+		ref.Src = src
+		ref.Dst = strings.TrimPrefix(dst, "refs/heads/")
+		op.Refs = append(op.Refs, ref)
+	}
+}
+
+// parseCheckout resolves the branch a checkout or switch moves to.
+// It reports false for file checkouts, which don't change branches.
+func parseCheckout(op *GitOp, sub string, args []string, previous string) bool {
+	createFlags := []string{"-b", "-B"}
+	if sub == "switch" {
+		createFlags = []string{"-c", "-C", "--create", "--force-create"}
+	}
+	for _, flag := range createFlags {
+		if name, ok := flagValue(args, "", flag); ok {
+			op.Target, op.Create = name, true
+			return true
+		}
+	}
+
+	// `git checkout -- file` and `git checkout main -- file` restore files
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+	}
+
+	pos := positionalArgs(args, "--orphan", "-t", "--track", "--conflict")
+	if len(pos) != 1 || pos[0] == "." {
+		return false
+	}
+	op.Target = pos[0]
+	if op.Target == "-" {
+		op.Target = previous
+	}
+	if hasFlag(args, "--detach") {
+		op.Target = ""
+	}
+	return true
+}
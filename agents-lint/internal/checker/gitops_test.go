@@ -0,0 +1,150 @@
+package checker
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func bashTranscript(commands ...string) *transcript.Transcript {
+	tr := &transcript.Transcript{}
+	for i, command := range commands {
+		input, _ := json.Marshal(BashInput{Command: command})
+		tr.ToolCalls = append(tr.ToolCalls, transcript.ToolCall{
+			ID:    "t" + string(rune('1'+i)),
+			Name:  "Bash",
+			Input: input,
+		})
+	}
+	return tr
+}
+
+func TestGitOps_PushRefspecs(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		remote  string
+		refs    []RefUpdate
+		force   bool
+	}{
+		{"branch", "git push origin feature", "origin", []RefUpdate{{Src: "feature", Dst: "feature"}}, false},
+		{"explicit dst", "git push upstream HEAD:main", "upstream", []RefUpdate{{Src: "HEAD", Dst: "main"}}, false},
+		{"HEAD, unknown branch", "git push origin HEAD", "origin", []RefUpdate{{Src: "HEAD"}}, false},
+		{"forced refspec", "git push origin +main", "origin", []RefUpdate{{Src: "main", Dst: "main", Force: true}}, false},
+		{"full ref", "git push origin feature:refs/heads/master", "origin", []RefUpdate{{Src: "feature", Dst: "master"}}, false},
+		{"lease", "git push --force-with-lease origin feature", "origin", []RefUpdate{{Src: "feature", Dst: "feature"}}, true},
+		{"lease with value", "git push --force-with-lease=feature:abc123 origin feature", "origin", []RefUpdate{{Src: "feature", Dst: "feature"}}, true},
+		{"delete refspec", "git push origin :main", "origin", []RefUpdate{{Dst: "main", Delete: true}}, false},
+		{"delete flag", "git push -d origin old", "origin", []RefUpdate{{Dst: "old", Delete: true}}, false},
+		{"no refspec, unknown branch", "git push", "origin", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := GitOps(bashTranscript(tt.command))
+			if len(ops) != 1 || ops[0].Kind != GitOpPush {
+				t.Fatalf("ops = %+v, want single push", ops)
+			}
+			op := ops[0]
+			if op.Remote != tt.remote || op.Force != tt.force || !reflect.DeepEqual(op.Refs, tt.refs) {
+				t.Errorf("push = remote %q force %v refs %+v; want %q %v %+v", op.Remote, op.Force, op.Refs, tt.remote, tt.force, tt.refs)
+			}
+		})
+	}
+}
+
+func TestGitOps_TracksBranch(t *testing.T) {
+	tr := bashTranscript(
+		"git checkout main",
+		"git pull && git checkout -b AGENTS-7",
+		"git commit -m 'Add thing'",
+		"git switch -",
+		"git push origin HEAD",
+	)
+
+	ops := GitOps(tr)
+	var branches []string
+	for _, op := range ops {
+		branches = append(branches, op.Kind.String()+"@"+op.Branch)
+	}
+	want := []string{"checkout@", "checkout@main", "commit@AGENTS-7", "checkout@AGENTS-7", "push@main"}
+	if !reflect.DeepEqual(branches, want) {
+		t.Errorf("ops = %v, want %v", branches, want)
+	}
+
+	push := ops[len(ops)-1]
+	if len(push.Refs) != 1 || push.Refs[0].Dst != "main" {
+		t.Errorf("push refs = %+v, want HEAD resolved to main", push.Refs)
+	}
+}
+
+func TestGitOps_FailedCheckoutKeepsBranch(t *testing.T) {
+	tr := bashTranscript("git checkout feature", "git checkout main", "git push")
+	tr.ToolCalls[1].IsError = true
+
+	ops := GitOps(tr)
+	if got := ops[2].Branch; got != "feature" {
+		t.Errorf("Branch after failed checkout = %q, want %q", got, "feature")
+	}
+}
+
+func TestGitOps_BranchFromOutput(t *testing.T) {
+	tr := bashTranscript("git status", "git push")
+	tr.ToolCalls[0].Result = "On branch main\nYour branch is up to date with 'origin/main'.\n"
+
+	ops := GitOps(tr)
+	if len(ops) != 1 || len(ops[0].Refs) != 1 || ops[0].Refs[0].Dst != "main" {
+		t.Errorf("ops = %+v, want push to main", ops)
+	}
+}
+
+func TestGitOps_BranchFromSessionLog(t *testing.T) {
+	tr := bashTranscript("git push")
+	tr.ToolCalls[0].EventUUID = "u1"
+	tr.Events = []any{transcript.AssistantEvent{Event: transcript.Event{UUID: "u1", GitBranch: "master"}}}
+
+	ops := GitOps(tr)
+	if len(ops) != 1 || ops[0].Branch != "master" {
+		t.Errorf("ops = %+v, want push from master", ops)
+	}
+}
+
+func TestGitOps_OtherCommands(t *testing.T) {
+	tests := []struct {
+		command string
+		want    GitOp
+	}{
+		{"git checkout -- main.go", GitOp{}},
+		{"git checkout main -- main.go", GitOp{}},
+		{"git switch -c AGENTS-1", GitOp{Kind: GitOpCheckout, Target: "AGENTS-1", Create: true}},
+		{"git merge --no-ff feature", GitOp{Kind: GitOpMerge, Target: "feature"}},
+		{"git branch AGENTS-2", GitOp{Kind: GitOpBranchCreate, Target: "AGENTS-2"}},
+		{"git branch -D AGENTS-2", GitOp{Kind: GitOpBranchDelete, Target: "AGENTS-2"}},
+		{"git worktree add -b AGENTS-3 ../repo-AGENTS-3", GitOp{Kind: GitOpWorktreeAdd, Target: "AGENTS-3", Create: true, Path: "../repo-AGENTS-3"}},
+		{"git worktree add ../repo-AGENTS-4", GitOp{Kind: GitOpWorktreeAdd, Target: "repo-AGENTS-4", Create: true, Path: "../repo-AGENTS-4"}},
+		{"git worktree add ../wt AGENTS-5", GitOp{Kind: GitOpWorktreeAdd, Target: "AGENTS-5", Path: "../wt"}},
+		{"git worktree remove --force ../wt", GitOp{Kind: GitOpWorktreeRemove, Path: "../wt", Force: true}},
+		{"git commit --amend --no-edit", GitOp{Kind: GitOpCommit, Amend: true}},
+	}
+
+	for _, tt := range tests {
+		ops := GitOps(bashTranscript(tt.command))
+		if reflect.DeepEqual(tt.want, GitOp{}) {
+			if len(ops) != 0 {
+				t.Errorf("%q: ops = %+v, want none", tt.command, ops)
+			}
+			continue
+		}
+		if len(ops) != 1 {
+			t.Errorf("%q: got %d ops, want 1", tt.command, len(ops))
+			continue
+		}
+		op := ops[0]
+		got := GitOp{Kind: op.Kind, Target: op.Target, Create: op.Create, Path: op.Path, Force: op.Force, Amend: op.Amend}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: op = %+v, want %+v", tt.command, got, tt.want)
+		}
+	}
+}
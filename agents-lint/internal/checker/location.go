@@ -0,0 +1,67 @@
+package checker
+
+import "github.com/michaellady/agents-lint/internal/transcript"
+
+// eventUUID returns the UUID of a parsed event.
+func eventUUID(event any) string {
+	if ev, ok := baseEvent(event); ok {
+		return ev.UUID
+	}
+	return ""
+}
+
+// baseEvent returns the common fields of a parsed event.
+func baseEvent(event any) (transcript.Event, bool) {
+	switch ev := event.(type) {
+	case transcript.SystemEvent:
+		return ev.Event, true
+	case transcript.AssistantEvent:
+		return ev.Event, true
+	case transcript.UserEvent:
+		return ev.Event, true
+	case transcript.ResultEvent:
+		return ev.Event, true
+	case transcript.SummaryEvent:
+		return ev.Event, true
+	}
+	return transcript.Event{}, false
+}
+
+// eventPositions maps event UUIDs and tool_use IDs to the index of their
+// event in t.Events.
+func eventPositions(t *transcript.Transcript) map[string]int {
+	positions := make(map[string]int)
+	for i, event := range t.Events {
+		if uuid := eventUUID(event); uuid != "" {
+			positions[uuid] = i
+		}
+	}
+	for _, tc := range t.ToolCalls {
+		if i, ok := positions[tc.EventUUID]; ok {
+			positions[tc.ID] = i
+		}
+	}
+	return positions
+}
+
+// position is the source location of an event in the transcript.
+type position struct {
+	line   int
+	offset int64
+}
+
+// sourcePositions maps event UUIDs and tool_use IDs to transcript positions.
+func sourcePositions(t *transcript.Transcript) map[string]position {
+	positions := make(map[string]position)
+	for _, event := range t.Events {
+		if ev, ok := baseEvent(event); ok && ev.UUID != "" && ev.Line > 0 {
+			positions[ev.UUID] = position{ev.Line, ev.Offset}
+		}
+	}
+	for _, tc := range t.ToolCalls {
+		if tc.ID != "" && tc.Line > 0 {
+			positions[tc.ID] = position{tc.Line, tc.Offset}
+		}
+	}
+	return positions
+}
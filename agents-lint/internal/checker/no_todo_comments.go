@@ -0,0 +1,315 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&NoTodoComments{})
+}
+
+// NoTodoComments checks that written files don't contain TODO comments or
+// Markdown task lists.
+// Per AGENTS.md Rule 2: "Never Use TODO Comments or Lists"
+type NoTodoComments struct{}
+
+func (c *NoTodoComments) ID() string {
+	return "no-todo-comments"
+}
+
+func (c *NoTodoComments) Description() string {
+	return "Ensures written code has no TODO/FIXME/XXX comments or task lists (Rule 2: use bd instead)"
+}
+
+func (c *NoTodoComments) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	for _, tc := range t.ToolCalls {
+		path, writes := writtenContent(tc)
+		for _, w := range writes {
+			for _, f := range findTodos(path, w.markdown, w.content) {
+				if w.previous != "" && strings.Contains(w.previous, strings.TrimSpace(f.text)) {
+					// Already there before the edit
+					continue
+				}
+				violations = append(violations, Violation{
+					CheckerID:  c.ID(),
+					Rule:       "Rule 2",
+					Severity:   SeverityError,
+					Message:    fmt.Sprintf("%s written to %s:%d; create a bead with bd instead", f.kind, filepath.Base(path), f.line),
+					EventUUID:  tc.EventUUID,
+					ToolCallID: tc.ID,
+					Context: map[string]string{
+						"file": path,
+						"line": fmt.Sprint(f.line),
+						"text": truncate(strings.TrimSpace(f.text), 100),
+					},
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// write is content a tool call writes to a file.
+type write struct {
+	content  string
+	previous string // text replaced by an edit, if any
+	markdown bool   // content is Markdown regardless of the file extension
+}
+
+// writtenContent returns the file path and new content of a Write, Edit,
+// MultiEdit, or NotebookEdit call.
+func writtenContent(tc transcript.ToolCall) (string, []write) {
+	var input struct {
+		FilePath     string `json:"file_path"`
+		Content      string `json:"content"`
+		OldString    string `json:"old_string"`
+		NewString    string `json:"new_string"`
+		NotebookPath string `json:"notebook_path"`
+		NewSource    string `json:"new_source"`
+		CellType     string `json:"cell_type"`
+		Edits        []struct {
+			OldString string `json:"old_string"`
+			NewString string `json:"new_string"`
+		} `json:"edits"`
+	}
+	if err := json.Unmarshal(tc.Input, &input); err != nil {
+		return "", nil
+	}
+
+	switch tc.Name {
+	case "Write":
+		return input.FilePath, []write{{content: input.Content}}
+	case "Edit":
+		return input.FilePath, []write{{content: input.NewString, previous: input.OldString}}
+	case "MultiEdit":
+		writes := make([]write, len(input.Edits))
+		for i, e := range input.Edits {
+			writes[i] = write{content: e.NewString, previous: e.OldString}
+		}
+		return input.FilePath, writes
+	case "NotebookEdit":
+		return input.NotebookPath, []write{{content: input.NewSource, markdown: input.CellType == "markdown"}}
+	}
+	return "", nil
+}
+
+// commentSyntax describes how a language writes comments.
+type commentSyntax struct {
+	line  []string    // line comment markers
+	block [][2]string // block comment delimiters
+
+	// singleQuotes is true if single quotes delimit strings rather than,
+	// say, characters or lifetimes that may be unbalanced.
+	singleQuotes bool
+
+	// multiline are delimiters of strings that may span lines, such as
+	// Go raw strings. Backslashes escape within them unless rawMultiline.
+	multiline    []string
+	rawMultiline bool
+
+	// docstrings is true if a multiline string starting a line is
+	// documentation, and so is scanned like a block comment.
+	docstrings bool
+
+	// wordStart is true if line comments only begin at the start of a
+	// word, so that shell's ${#arr[@]} isn't one.
+	wordStart bool
+}
+
+var (
+	cStyle     = &commentSyntax{line: []string{"//"}, block: [][2]string{{"/*", "*/"}}, multiline: []string{"`"}, rawMultiline: true}
+	jsStyle    = &commentSyntax{line: []string{"//"}, block: [][2]string{{"/*", "*/"}}, singleQuotes: true, multiline: []string{"`"}}
+	hashStyle  = &commentSyntax{line: []string{"#"}, singleQuotes: true, wordStart: true}
+	dashStyle  = &commentSyntax{line: []string{"--"}, block: [][2]string{{"/*", "*/"}}, singleQuotes: true}
+	htmlStyle  = &commentSyntax{block: [][2]string{{"<!--", "-->"}}}
+	cssStyle   = &commentSyntax{block: [][2]string{{"/*", "*/"}}, singleQuotes: true}
+	semiStyle  = &commentSyntax{line: []string{";"}}
+	pythonlike = &commentSyntax{line: []string{"#"}, singleQuotes: true, multiline: []string{`"""`, `'''`}, docstrings: true}
+)
+
+// commentSyntaxes maps file extensions, or base names for files without
+// one, to their comment syntax.
+var commentSyntaxes = map[string]*commentSyntax{
+	".go": cStyle, ".c": cStyle, ".h": cStyle, ".cc": cStyle, ".cpp": cStyle, ".hpp": cStyle,
+	".rs": cStyle, ".java": cStyle, ".kt": cStyle, ".scala": cStyle, ".swift": cStyle,
+	".cs": cStyle, ".dart": cStyle, ".proto": cStyle,
+	".js": jsStyle, ".jsx": jsStyle, ".ts": jsStyle, ".tsx": jsStyle, ".mjs": jsStyle,
+	".cjs": jsStyle, ".php": jsStyle, ".scss": jsStyle, ".less": jsStyle,
+	".py": pythonlike,
+	".sh": hashStyle, ".bash": hashStyle, ".zsh": hashStyle, ".rb": hashStyle, ".pl": hashStyle,
+	".r": hashStyle, ".yaml": hashStyle, ".yml": hashStyle, ".toml": hashStyle, ".tf": hashStyle,
+	".conf": hashStyle, ".cfg": hashStyle, ".mk": hashStyle,
+	"Dockerfile": hashStyle, "Makefile": hashStyle,
+	".sql": dashStyle, ".lua": dashStyle, ".hs": dashStyle,
+	".html": htmlStyle, ".xml": htmlStyle, ".svg": htmlStyle, ".vue": htmlStyle,
+	".css": cssStyle,
+	".ini": semiStyle, ".clj": semiStyle, ".lisp": semiStyle, ".el": semiStyle,
+}
+
+// markdownExtensions are checked for task lists instead of code comments.
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true, ".mdx": true}
+
+var (
+	// todoPattern matches a task marker within comment text.
+	todoPattern = regexp.MustCompile(`\b(TODO|FIXME|XXX)\b`)
+
+	// taskListPattern matches an unchecked Markdown task list item.
+	taskListPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+\[ \]`)
+)
+
+// todo is a TODO comment or task list item found in written content.
+type todo struct {
+	kind string // "TODO comment" or "Task list"
+	line int    // 1-based line within the written content
+	text string
+}
+
+// findTodos finds TODO comments and task list items in content written
+// to path. Files of unknown type aren't checked.
+func findTodos(path string, markdown bool, content string) []todo {
+	ext := strings.ToLower(filepath.Ext(path))
+	if markdown || markdownExtensions[ext] {
+		return findMarkdownTodos(content)
+	}
+	if ext == ".ipynb" {
+		return findCommentTodos(pythonlike, content)
+	}
+
+	syntax, ok := commentSyntaxes[ext]
+	if !ok {
+		syntax, ok = commentSyntaxes[filepath.Base(path)]
+	}
+	if !ok {
+		return nil
+	}
+	return findCommentTodos(syntax, content)
+}
+
+// findMarkdownTodos finds task list items, and TODOs in HTML comments.
+func findMarkdownTodos(content string) []todo {
+	todos := findCommentTodos(htmlStyle, content)
+	n := 0
+	for line := range strings.Lines(content) {
+		n++
+		if taskListPattern.MatchString(line) {
+			todos = append(todos, todo{kind: "Task list", line: n, text: line})
+		}
+	}
+	return todos
+}
+
+// findCommentTodos scans content line by line for comments containing a
+// task marker. Block comments and multiline strings are tracked across
+// lines; other string literals are skipped on a best-effort basis.
+func findCommentTodos(syntax *commentSyntax, content string) []todo {
+	var todos []todo
+	var closeBlock string  // end delimiter of the open block comment
+	var closeString string // end delimiter of the open multiline string
+	n := 0
+
+	for line := range strings.Lines(content) {
+		n++
+		var comment strings.Builder
+		var quote byte
+
+		for i := 0; i < len(line); i++ {
+			rest := line[i:]
+			switch {
+			case closeBlock != "":
+				if strings.HasPrefix(rest, closeBlock) {
+					i += len(closeBlock) - 1
+					closeBlock = ""
+				} else {
+					comment.WriteByte(line[i])
+				}
+			case closeString != "":
+				if line[i] == '\\' && !syntax.rawMultiline {
+					i++
+				} else if strings.HasPrefix(rest, closeString) {
+					i += len(closeString) - 1
+					closeString = ""
+				}
+			case quote != 0:
+				if line[i] == '\\' {
+					i++
+				} else if line[i] == quote {
+					quote = 0
+				}
+			default:
+				if delim, ok := hasPrefix(syntax.multiline, rest); ok {
+					if syntax.docstrings && strings.TrimSpace(line[:i]) == "" {
+						closeBlock = delim
+					} else {
+						closeString = delim
+					}
+					i += len(delim) - 1
+					continue
+				}
+				if open, ok := blockOpen(syntax, rest); ok {
+					i += len(open[0]) - 1
+					closeBlock = open[1]
+					continue
+				}
+				switch {
+				case line[i] == '\'' && !syntax.singleQuotes:
+					// Skip a character literal such as '"' or '\''
+					j := i + 1
+					if j < len(line) && line[j] == '\\' {
+						j++
+					}
+					if j+1 < len(line) && line[j+1] == '\'' {
+						i = j + 1
+					}
+				case line[i] == '"' || line[i] == '`' || line[i] == '\'':
+					quote = line[i]
+				case lineComment(syntax, line, i):
+					comment.WriteString(rest)
+					i = len(line)
+				}
+			}
+		}
+
+		if todoPattern.MatchString(comment.String()) {
+			todos = append(todos, todo{kind: "TODO comment", line: n, text: line})
+		}
+	}
+	return todos
+}
+
+// blockOpen reports whether s starts with a block comment opener.
+func blockOpen(syntax *commentSyntax, s string) ([2]string, bool) {
+	for _, delims := range syntax.block {
+		if strings.HasPrefix(s, delims[0]) {
+			return delims, true
+		}
+	}
+	return [2]string{}, false
+}
+
+// hasPrefix returns the first of prefixes that s starts with.
+func hasPrefix(prefixes []string, s string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// lineComment reports whether a line comment marker starts at line[i].
+func lineComment(syntax *commentSyntax, line string, i int) bool {
+	if syntax.wordStart && i > 0 && !strings.ContainsRune(" \t;&|()", rune(line[i-1])) {
+		return false
+	}
+	_, ok := hasPrefix(syntax.line, line[i:])
+	return ok
+}
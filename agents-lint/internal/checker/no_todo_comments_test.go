@@ -0,0 +1,147 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func TestNoTodoComments_ID(t *testing.T) {
+	c := &NoTodoComments{}
+	if c.ID() != "no-todo-comments" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "no-todo-comments")
+	}
+}
+
+func TestFindTodos(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []int // lines
+	}{
+		{"go line comment", "main.go", "package main\n\n// TODO: handle errors\nfunc main() {}\n", []int{3}},
+		{"go trailing comment", "main.go", "x := 1 // FIXME overflow\n", []int{1}},
+		{"go block comment", "main.go", "/*\n * XXX: racy\n */\nvar x int\n", []int{2}},
+		{"go string literal", "main.go", "fmt.Println(\"// TODO\")\n", nil},
+		{"go raw string", "main.go", "s := `# TODO`\n", nil},
+		{"go multiline raw string", "main.go", "usage := `\n// TODO is reported\n`\nx := 1 // TODO\n", []int{4}},
+		{"go raw string ending in backslash", "main.go", "dir := `C:\\`  // TODO\n", []int{1}},
+		{"js template literal", "app.ts", "const q = `\n  // TODO ${x} \\` here\n`; // FIXME\n", []int{3}},
+		{"go identifier", "main.go", "// Todos are tracked in bd\ntype TODOList struct{}\n", nil},
+		{"go comment after rune", "main.go", "c := '\"' // TODO(alice): escape\n", []int{1}},
+		{"rust lifetime", "lib.rs", "fn f<'a>(x: &'a str) {} // TODO\n", []int{1}},
+		{"typescript", "app.tsx", "const a = 'b'; // TODO remove\n", []int{1}},
+		{"python", "app.py", "import os\n# TODO: cache\n", []int{2}},
+		{"python hash in string", "app.py", "url = 'http://x/#TODO'\n", nil},
+		{"python docstring", "app.py", "def f():\n    \"\"\"\n    TODO: document\n    \"\"\"\n", []int{3}},
+		{"python multiline string", "app.py", "HELP = '''\n# TODO: not a comment\n'''\n", nil},
+		{"shell", "deploy.sh", "echo hi # FIXME\n", []int{1}},
+		{"shell parameter length", "deploy.sh", "echo ${#arr[@]} TODO\nn=$# # TODO count\n", []int{2}},
+		{"shell comment after operator", "deploy.sh", "make;# XXX\n", []int{1}},
+		{"dockerfile", "build/Dockerfile", "# TODO pin version\nFROM golang\n", []int{1}},
+		{"sql", "schema.sql", "-- TODO: index\nCREATE TABLE t (id int);\n", []int{1}},
+		{"html", "index.html", "<div>\n<!-- TODO: nav -->\n</div>\n", []int{2}},
+		{"hash is not a comment in go", "main.go", "# TODO\n", nil},
+		{"unknown extension", "notes.txt", "TODO: everything\n", nil},
+		{"markdown task list", "PLAN.md", "# Plan\n\n- [ ] Write tests\n- [x] Write code\n* [ ] Ship\n", []int{3, 5}},
+		{"markdown numbered task", "README.md", "1. [ ] Install\n", []int{1}},
+		{"markdown html comment", "README.md", "Intro\n<!-- TODO: examples -->\n", []int{2}},
+		{"markdown prose", "README.md", "Never use TODO comments.\n", nil},
+		{"notebook", "analysis.ipynb", "df = load()\n# TODO: plot\n", []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, f := range findTodos(tt.path, false, tt.content) {
+				got = append(got, f.line)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findTodos(%q) lines = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoTodoComments_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolName string
+		input    map[string]any
+		want     []string // file:line
+	}{
+		{
+			name:     "write",
+			toolName: "Write",
+			input:    map[string]any{"file_path": "/repo/main.go", "content": "package main\n\n// TODO: implement\n"},
+			want:     []string{"/repo/main.go:3"},
+		},
+		{
+			name:     "edit adds todo",
+			toolName: "Edit",
+			input:    map[string]any{"file_path": "/repo/main.go", "old_string": "x := 1", "new_string": "x := 1\n// FIXME: magic number"},
+			want:     []string{"/repo/main.go:2"},
+		},
+		{
+			name:     "edit keeps existing todo",
+			toolName: "Edit",
+			input:    map[string]any{"file_path": "/repo/main.go", "old_string": "// TODO: retry\nx := 1", "new_string": "// TODO: retry\nx := 2"},
+		},
+		{
+			name:     "multi edit",
+			toolName: "MultiEdit",
+			input: map[string]any{"file_path": "/repo/app.py", "edits": []any{
+				map[string]any{"old_string": "a", "new_string": "b"},
+				map[string]any{"old_string": "c", "new_string": "d  # XXX"},
+			}},
+			want: []string{"/repo/app.py:1"},
+		},
+		{
+			name:     "notebook code cell",
+			toolName: "NotebookEdit",
+			input:    map[string]any{"notebook_path": "/repo/a.ipynb", "new_source": "x = 1\n# TODO", "cell_type": "code"},
+			want:     []string{"/repo/a.ipynb:2"},
+		},
+		{
+			name:     "notebook markdown cell",
+			toolName: "NotebookEdit",
+			input:    map[string]any{"notebook_path": "/repo/a.ipynb", "new_source": "## Next\n- [ ] clean data", "cell_type": "markdown"},
+			want:     []string{"/repo/a.ipynb:2"},
+		},
+		{
+			name:     "clean write",
+			toolName: "Write",
+			input:    map[string]any{"file_path": "/repo/main.go", "content": "package main\n"},
+		},
+		{
+			name:     "read is ignored",
+			toolName: "Read",
+			input:    map[string]any{"file_path": "/repo/main.go", "content": "// TODO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &transcript.Transcript{
+				ToolCalls: []transcript.ToolCall{
+					{ID: "t1", Name: tt.toolName, EventUUID: "e1", Input: toRawJSON(tt.input)},
+				},
+			}
+
+			c := &NoTodoComments{}
+			var got []string
+			for _, v := range c.Check(tr) {
+				if v.Rule != "Rule 2" || v.Severity != SeverityError || v.ToolCallID != "t1" {
+					t.Errorf("unexpected violation: %+v", v)
+				}
+				got = append(got, v.Context["file"]+":"+v.Context["line"])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
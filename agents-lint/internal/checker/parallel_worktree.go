@@ -2,6 +2,10 @@ package checker
 
 import (
 	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
@@ -14,7 +18,11 @@ func init() {
 // ParallelWorktree checks that parallel agents use git worktrees.
 // Per AGENTS.md Rule 8: "Each parallel agent uses its own git worktree:
 // `git worktree add ../REPO-ISSUE-ID -b ISSUE-ID main`"
-type ParallelWorktree struct{}
+type ParallelWorktree struct {
+	// ExemptAgentTypes are subagent types that don't need a worktree.
+	// Defaults to exemptAgentTypes if nil.
+	ExemptAgentTypes []string
+}
 
 func (c *ParallelWorktree) ID() string {
 	return "parallel-worktree"
@@ -24,68 +32,376 @@ func (c *ParallelWorktree) Description() string {
 	return "Ensures parallel agents use git worktrees (Rule 8)"
 }
 
+// Configure sets options from the project configuration file.
+func (c *ParallelWorktree) Configure(decode func(any) error) error {
+	var opts struct {
+		ExemptAgentTypes []string `yaml:"exempt_agent_types"`
+	}
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	if opts.ExemptAgentTypes != nil {
+		c.ExemptAgentTypes = opts.ExemptAgentTypes
+	}
+	return nil
+}
+
 // exemptAgentTypes are agents that don't write code and don't need worktrees
-var exemptAgentTypes = map[string]bool{
-	"Explore":          true, // Read-only exploration
-	"Plan":             true, // Planning only
-	"claude-code-guide": true, // Documentation lookup
-	"statusline-setup": true, // Configuration only
+var exemptAgentTypes = []string{
+	"Explore",           // Read-only exploration
+	"Plan",              // Planning only
+	"claude-code-guide", // Documentation lookup
+	"statusline-setup",  // Configuration only
 }
 
 // isExemptAgent checks if the agent type is exempt from worktree requirement
-func isExemptAgent(agentType string) bool {
-	return exemptAgentTypes[agentType]
+func (c *ParallelWorktree) isExemptAgent(agentType string) bool {
+	exempt := c.ExemptAgentTypes
+	if exempt == nil {
+		exempt = exemptAgentTypes
+	}
+	return slices.Contains(exempt, agentType)
+}
+
+// worktree is a linked worktree created during the session.
+type worktree struct {
+	add     GitOp
+	owner   *transcript.Agent
+	merged  *transcript.ToolCall // merge of its branch or PR, if any
+	removed bool
+}
+
+// spawn is a non-exempt Task call and what the session shows about the
+// worktree its subagent works in.
+type spawn struct {
+	tc        transcript.ToolCall
+	owner     *transcript.Agent
+	prompt    string
+	available bool // the spawning agent or an ancestor had created a worktree
+}
+
+// pullNumberPattern matches the PR number in a pull request URL.
+var pullNumberPattern = regexp.MustCompile(`/pull/(\d+)`)
+
+// ghPRValueFlags are gh pr create and merge options that take a value.
+var ghPRValueFlags = []string{
+	"-b", "--body", "-F", "--body-file", "-t", "--title", "--subject",
+	"-A", "--author-email", "--match-head-commit", "-R", "--repo",
+	"-B", "--base", "-H", "--head", "-a", "--assignee", "-l", "--label",
+	"-m", "--milestone", "-p", "--project", "-r", "--reviewer",
 }
 
 func (c *ParallelWorktree) Check(t *transcript.Transcript) []Violation {
 	var violations []Violation
 
-	// Track whether a worktree has been created
-	worktreeCreated := false
+	root := t.AgentTree()
+	agents := make(map[string]*transcript.Agent)
+	for agent := range root.All() {
+		agents[agent.ID()] = agent
+	}
+	ownerOf := func(tc transcript.ToolCall) *transcript.Agent {
+		if owner := agents[tc.ParentToolUseID]; owner != nil {
+			return owner
+		}
+		return root
+	}
 
-	for _, tc := range t.ToolCalls {
-		// Check for git worktree add command
-		if tc.Name == "Bash" {
-			var input struct {
-				Command string `json:"command"`
-			}
-			if err := json.Unmarshal(tc.Input, &input); err != nil {
-				continue
+	violation := func(tc transcript.ToolCall, message string, context map[string]string) {
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 8",
+			Severity:   SeverityWarning,
+			Message:    message,
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Agent:      ownerOf(tc).Name(),
+			Context:    context,
+		})
+	}
+
+	worktrees := make(map[string]*worktree)
+	var order []string
+	used := make(map[*transcript.Agent]map[string]bool) // worktrees each agent worked in
+	prBranches := make(map[string]string)               // PR number -> head branch
+	var spawns []spawn
+
+	// byBranch returns the worktree checked out on branch that is still present.
+	byBranch := func(branch string) *worktree {
+		for _, path := range order {
+			if wt := worktrees[path]; wt.add.Target == branch && !wt.removed {
+				return wt
 			}
+		}
+		return nil
+	}
+
+	replaySession(t, func(tc transcript.ToolCall, state State, ops []GitOp) bool {
+		owner := ownerOf(tc)
 
-			if strings.Contains(input.Command, "git worktree add") {
-				worktreeCreated = true
+		// Bash calls run in the worktree containing their directory; file
+		// tools work in the worktree containing their path.
+		for _, dir := range []string{state.Worktree, toolPath(tc)} {
+			if path := containingWorktree(order, dir); path != "" {
+				if used[owner] == nil {
+					used[owner] = make(map[string]bool)
+				}
+				used[owner][path] = true
 			}
 		}
 
-		// Check for Task tool invocations
-		if tc.Name == "Task" {
+		if tc.Name == transcript.TaskTool {
 			var input struct {
 				SubagentType string `json:"subagent_type"`
 				Prompt       string `json:"prompt"`
 			}
-			if err := json.Unmarshal(tc.Input, &input); err != nil {
+			if err := json.Unmarshal(tc.Input, &input); err != nil || c.isExemptAgent(input.SubagentType) {
+				return true
+			}
+			available := slices.ContainsFunc(order, func(path string) bool {
+				return isAncestor(worktrees[path].owner, owner)
+			})
+			spawns = append(spawns, spawn{tc: tc, owner: owner, prompt: input.Prompt, available: available})
+			return true
+		}
+
+		if tc.IsError {
+			return true
+		}
+
+		for _, op := range ops {
+			switch op.Kind {
+			case GitOpWorktreeAdd:
+				worktrees[op.Path] = &worktree{add: op, owner: owner}
+				order = append(order, op.Path)
+				if message, ok := c.checkName(t.CWD, op); ok {
+					violation(tc, message, map[string]string{"command": truncate(op.Command.Source, 100)})
+				}
+			case GitOpWorktreeRemove:
+				if wt := worktrees[op.Path]; wt != nil {
+					wt.removed = true
+				}
+				if op.Force {
+					violation(tc, fmt.Sprintf("Worktree %s removed with --force, discarding its uncommitted changes; commit them before removing it", relPath(t.CWD, op.Path)),
+						map[string]string{"command": truncate(op.Command.Source, 100)})
+				}
+			case GitOpMerge:
+				if wt := byBranch(op.Target); wt != nil && wt.merged == nil {
+					wt.merged = &tc
+				}
+			}
+		}
+
+		// gh works on the branch checked out where it runs, which an
+		// earlier cd in the same call may have changed
+		_, cmds, _ := bashCommands(tc)
+		dir, branch := state.CWD, state.Branch
+		for _, cmd := range cmds {
+			if cmd.Program() == "cd" {
+				dir = chdir(dir, "", cmd.Argv()[1:])
+				branch = ""
+				if wt := worktrees[containingWorktree(order, dir)]; wt != nil {
+					branch = wt.add.Target
+				}
 				continue
 			}
+			sub, args, ok := cmd.Subcommand("gh")
+			if !ok || sub != "pr" || len(args) == 0 {
+				continue
+			}
+			switch args[0] {
+			case "create":
+				head := branch
+				if h, ok := flagValue(args[1:], "-H", "--head"); ok {
+					head = h
+				}
+				if m := pullNumberPattern.FindStringSubmatch(tc.Result); m != nil && head != "" {
+					prBranches[m[1]] = head
+				}
+			case "merge":
+				merged := branch
+				if pos := positionalArgs(args[1:], ghPRValueFlags...); len(pos) > 0 {
+					merged = pos[0]
+					if m := pullNumberPattern.FindStringSubmatch(pos[0]); m != nil {
+						merged = prBranches[m[1]]
+					} else if b, ok := prBranches[strings.TrimPrefix(pos[0], "#")]; ok {
+						merged = b
+					}
+				}
+				if wt := byBranch(merged); wt != nil && wt.merged == nil {
+					wt.merged = &tc
+				}
+			}
+		}
+		return true
+	})
+
+	// Each subagent works in the worktree its prompt names or its commands
+	// run in, which no unrelated agent may share.
+	assigned := make(map[*transcript.Agent]string)
+	var assignedOrder []*transcript.Agent
+	for _, s := range spawns {
+		agent := agents[s.tc.ID]
+		if agent == nil {
+			agent = &transcript.Agent{Task: &s.tc, Parent: s.owner}
+		}
+
+		created := slices.ContainsFunc(order, func(path string) bool {
+			return isAncestor(agent, worktrees[path].owner)
+		})
+		if !s.available && !created {
+			violation(s.tc, "Parallel agent spawned without git worktree; use `git worktree add` before spawning agents", nil)
+			continue
+		}
 
-			// Skip exempt agent types
-			if isExemptAgent(input.SubagentType) {
+		path := subagentWorktree(agent, s.prompt, order, used)
+		if path == "" {
+			// A nested Task works in its spawning agent's worktree
+			if inherited, ok := assigned[s.owner]; ok {
+				assigned[agent] = inherited
 				continue
 			}
+			violation(s.tc, "Subagent doesn't work in a worktree; cd into its worktree in the Task prompt or the subagent's commands", nil)
+			continue
+		}
+		assigned[agent] = path
+		assignedOrder = append(assignedOrder, agent)
 
-			// If no worktree created before this Task, flag it
-			if !worktreeCreated {
-				violations = append(violations, Violation{
-					CheckerID:  c.ID(),
-					Rule:       "Rule 8",
-					Severity:   SeverityWarning,
-					Message:    "Parallel agent spawned without git worktree; use `git worktree add` before spawning agents",
-					EventUUID:  tc.EventUUID,
-					ToolCallID: tc.ID,
-				})
+		for _, other := range assignedOrder {
+			if assigned[other] != path || isAncestor(other, agent) || isAncestor(agent, other) {
+				continue
 			}
+			violation(s.tc, fmt.Sprintf("Subagent shares worktree %s with %s; give each parallel agent its own worktree", relPath(t.CWD, path), other.Name()),
+				map[string]string{"worktree": path})
+			break
+		}
+	}
+
+	for _, path := range order {
+		wt := worktrees[path]
+		if wt.merged == nil || wt.removed {
+			continue
 		}
+		rel := relPath(t.CWD, path)
+		violation(*wt.merged, fmt.Sprintf("Worktree %s was not removed after its PR merged; run `git worktree remove %s`", rel, rel),
+			map[string]string{"worktree": path, "branch": wt.add.Target})
 	}
 
 	return violations
 }
+
+// subagentWorktree returns the worktree a subagent works in: the first one
+// its prompt names, or else the first one it or its subagents worked in.
+func subagentWorktree(agent *transcript.Agent, prompt string, order []string, used map[*transcript.Agent]map[string]bool) string {
+	for _, path := range order {
+		if mentionsPath(prompt, path) {
+			return path
+		}
+	}
+	for _, path := range order {
+		for a := range agent.All() {
+			if used[a][path] {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// checkName describes how a new worktree strays from the
+// ../REPO-ISSUE-ID convention: a sibling of the repository named after it
+// and the branch the worktree checks out.
+func (c *ParallelWorktree) checkName(root string, op GitOp) (string, bool) {
+	repo := ""
+	if root != "" {
+		repo = filepath.Base(root)
+	}
+	base := filepath.Base(op.Path)
+
+	var ok bool
+	switch {
+	case op.Target == "":
+		ok = repo == "" || strings.HasPrefix(base, repo+"-")
+	case op.Create && op.Target == base:
+		// The branch was named after the directory
+		ok = repo == "" || strings.HasPrefix(base, repo+"-") && len(base) > len(repo)+1
+	case repo == "":
+		ok = strings.HasSuffix(base, "-"+op.Target) && len(base) > len(op.Target)+1
+	default:
+		ok = base == repo+"-"+op.Target
+	}
+	if filepath.Dir(op.Path) != resolvePath(root, "..") {
+		ok = false
+	}
+	if ok {
+		return "", false
+	}
+
+	message := fmt.Sprintf("Worktree %s does not follow the ../REPO-ISSUE-ID naming convention", relPath(root, op.Path))
+	if repo != "" && op.Target != "" && !(op.Create && op.Target == base) {
+		message += fmt.Sprintf("; use ../%s-%s", repo, op.Target)
+	}
+	return message, true
+}
+
+// isAncestor reports whether a is agent or one of its ancestors.
+func isAncestor(a, agent *transcript.Agent) bool {
+	for ; agent != nil; agent = agent.Parent {
+		if agent == a {
+			return true
+		}
+	}
+	return false
+}
+
+// containingWorktree returns the worktree in paths containing path.
+func containingWorktree(paths []string, path string) string {
+	if path == "" {
+		return ""
+	}
+	for _, wt := range paths {
+		if path == wt || strings.HasPrefix(path, wt+"/") {
+			return wt
+		}
+	}
+	return ""
+}
+
+// toolPath returns the file or directory a tool call works on.
+func toolPath(tc transcript.ToolCall) string {
+	var input struct {
+		FilePath     string `json:"file_path"`
+		NotebookPath string `json:"notebook_path"`
+		Path         string `json:"path"`
+	}
+	if tc.Name == "Bash" || json.Unmarshal(tc.Input, &input) != nil {
+		return ""
+	}
+	switch {
+	case input.FilePath != "":
+		return filepath.Clean(input.FilePath)
+	case input.NotebookPath != "":
+		return filepath.Clean(input.NotebookPath)
+	case input.Path != "":
+		return filepath.Clean(input.Path)
+	}
+	return ""
+}
+
+// mentionsPath reports whether text names the directory path, in full or
+// by its base name (as in `cd ../repo-AGENTS-1`).
+func mentionsPath(text, path string) bool {
+	pattern := `(^|[\s/'"=` + "`" + `])` + regexp.QuoteMeta(filepath.Base(path)) + `($|[^\w-])`
+	return strings.Contains(text, path) || regexp.MustCompile(pattern).MatchString(text)
+}
+
+// relPath returns path relative to the session's working directory when
+// it is known.
+func relPath(root, path string) string {
+	if root == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
@@ -2,6 +2,7 @@ package checker
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
@@ -69,7 +70,7 @@ func TestParallelWorktree_TaskWithWorktreeAllowed(t *testing.T) {
 	tr := &transcript.Transcript{
 		ToolCalls: []transcript.ToolCall{
 			bashCall("t1", "e1", "git worktree add ../myrepo-ISSUE-123 -b ISSUE-123 main"),
-			taskCall("t2", "e2", "Implement feature X in the worktree"),
+			taskCall("t2", "e2", "cd ../myrepo-ISSUE-123 and implement feature X"),
 		},
 	}
 
@@ -82,20 +83,23 @@ func TestParallelWorktree_TaskWithWorktreeAllowed(t *testing.T) {
 }
 
 func TestParallelWorktree_MultipleTasksOneWorktree(t *testing.T) {
-	// One worktree covers all subsequent Tasks (within same branch context)
+	// Each parallel agent needs its own worktree
 	tr := &transcript.Transcript{
 		ToolCalls: []transcript.ToolCall{
 			bashCall("t1", "e1", "git worktree add ../myrepo-ISSUE-123 -b ISSUE-123 main"),
-			taskCall("t2", "e2", "First parallel task"),
-			taskCall("t3", "e3", "Second parallel task"),
+			taskCall("t2", "e2", "First parallel task in ../myrepo-ISSUE-123"),
+			taskCall("t3", "e3", "Second parallel task in ../myrepo-ISSUE-123"),
 		},
 	}
 
 	c := &ParallelWorktree{}
 	violations := c.Check(tr)
 
-	if len(violations) != 0 {
-		t.Errorf("expected 0 violations for multiple tasks after worktree, got %d", len(violations))
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation for tasks sharing a worktree, got %d", len(violations))
+	}
+	if violations[0].ToolCallID != "t3" {
+		t.Errorf("ToolCallID = %q, want %q", violations[0].ToolCallID, "t3")
 	}
 }
 
@@ -218,31 +222,38 @@ func TestParallelWorktree_NoTasksNoViolation(t *testing.T) {
 }
 
 func TestParallelWorktree_WorktreeVariations(t *testing.T) {
-	// Different valid worktree command formats
+	// Worktrees are named ../REPO-ISSUE-ID after the branch they check out
 	tests := []struct {
 		name    string
 		command string
+		want    []string // violation messages
 	}{
-		{"basic", "git worktree add ../myrepo-ISSUE-1 -b ISSUE-1 main"},
-		{"with path spaces", "git worktree add \"../my repo-ISSUE-1\" -b ISSUE-1 main"},
-		{"different base", "git worktree add ../feature -b feature-branch develop"},
-		{"short form", "git worktree add ../work"},
+		{"basic", "git worktree add ../myrepo-ISSUE-1 -b ISSUE-1 main", nil},
+		{"with path spaces", "git worktree add \"../my repo-ISSUE-1\" -b ISSUE-1 main", []string{"Worktree ../my repo-ISSUE-1 does not follow the ../REPO-ISSUE-ID naming convention; use ../myrepo-ISSUE-1"}},
+		{"different base", "git worktree add ../myrepo-ISSUE-1 -b ISSUE-1 develop", nil},
+		{"branch named after directory", "git worktree add ../myrepo-ISSUE-1", nil},
+		{"existing branch", "git worktree add ../myrepo-ISSUE-1 ISSUE-1", nil},
+		{"not named after branch", "git worktree add ../feature -b feature-branch develop", []string{"Worktree ../feature does not follow the ../REPO-ISSUE-ID naming convention; use ../myrepo-feature-branch"}},
+		{"short form", "git worktree add ../work", []string{"Worktree ../work does not follow the ../REPO-ISSUE-ID naming convention"}},
+		{"inside repository", "git worktree add .worktrees/myrepo-ISSUE-1 -b ISSUE-1", []string{"Worktree .worktrees/myrepo-ISSUE-1 does not follow the ../REPO-ISSUE-ID naming convention; use ../myrepo-ISSUE-1"}},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &transcript.Transcript{
+				CWD: "/src/myrepo",
 				ToolCalls: []transcript.ToolCall{
 					bashCall("t1", "e1", tt.command),
-					taskCall("t2", "e2", "Do work"),
 				},
 			}
 
 			c := &ParallelWorktree{}
-			violations := c.Check(tr)
-
-			if len(violations) != 0 {
-				t.Errorf("expected 0 violations for worktree command %q, got %d", tt.command, len(violations))
+			var got []string
+			for _, v := range c.Check(tr) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -254,3 +265,215 @@ func TestParallelWorktree_Registered(t *testing.T) {
 		t.Error("parallel-worktree checker not registered")
 	}
 }
+
+func TestParallelWorktree_SubagentCreatesOwnWorktree(t *testing.T) {
+	// A subagent that sets up its own worktree satisfies Rule 8
+	worktree := bashCall("t2", "e2", "git worktree add ../repo-ISSUE-1 -b ISSUE-1 main && cd ../repo-ISSUE-1")
+	worktree.ParentToolUseID = "t1"
+	test := bashCall("t3", "e3", "go test ./...")
+	test.ParentToolUseID = "t1"
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			taskCall("t1", "e1", "Implement ISSUE-1"),
+			worktree,
+			test,
+		},
+	}
+
+	c := &ParallelWorktree{}
+	violations := c.Check(tr)
+
+	if len(violations) != 0 {
+		t.Errorf("expected 0 violations when subagent creates its own worktree, got %d", len(violations))
+	}
+}
+
+func TestParallelWorktree_SiblingWorktreeDoesNotCount(t *testing.T) {
+	// A worktree created inside one subagent does not cover a later sibling
+	worktree := bashCall("t2", "e2", "git worktree add ../repo-ISSUE-1 -b ISSUE-1 main")
+	worktree.ParentToolUseID = "t1"
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			taskCall("t1", "e1", "Implement ISSUE-1; work in ../repo-ISSUE-1"),
+			worktree,
+			taskCall("t3", "e3", "Implement ISSUE-2"),
+		},
+	}
+
+	c := &ParallelWorktree{}
+	violations := c.Check(tr)
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation for sibling without worktree, got %d", len(violations))
+	}
+	if violations[0].ToolCallID != "t3" {
+		t.Errorf("ToolCallID = %q, want %q", violations[0].ToolCallID, "t3")
+	}
+	if violations[0].Agent != "main" {
+		t.Errorf("Agent = %q, want %q", violations[0].Agent, "main")
+	}
+}
+
+func TestParallelWorktree_NestedTaskReportsSpawningAgent(t *testing.T) {
+	nested := taskCall("t2", "e2", "Help with ISSUE-1")
+	nested.ParentToolUseID = "t1"
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			bashCall("t0", "e0", "git worktree add ../repo-ISSUE-1 -b ISSUE-1 main"),
+			taskCall("t1", "e1", "Implement ISSUE-1 in ../repo-ISSUE-1"),
+			nested,
+		},
+	}
+
+	c := &ParallelWorktree{}
+	violations := c.Check(tr)
+
+	// The nested Task inherits the worktree created by its ancestor
+	if len(violations) != 0 {
+		t.Errorf("expected 0 violations for nested Task under worktree, got %d", len(violations))
+	}
+}
+
+func TestParallelWorktree_ConfiguredExemptTypes(t *testing.T) {
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			taskCall("t1", "e1", "Review the diff"),
+		},
+	}
+
+	c := &ParallelWorktree{}
+	err := c.Configure(func(v any) error {
+		return json.Unmarshal([]byte(`{"ExemptAgentTypes":["general-purpose"]}`), v)
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if violations := c.Check(tr); len(violations) != 0 {
+		t.Errorf("expected configured type to be exempt, got %v", violations)
+	}
+}
+
+// subagentCall marks a tool call as made by the subagent of Task taskID.
+func subagentCall(taskID string, tc transcript.ToolCall) transcript.ToolCall {
+	tc.ParentToolUseID = taskID
+	return tc
+}
+
+func TestParallelWorktree_Check(t *testing.T) {
+	edit := func(id, path string) transcript.ToolCall {
+		input, _ := json.Marshal(map[string]string{"file_path": path, "old_string": "a", "new_string": "b"})
+		return transcript.ToolCall{ID: id, Name: "Edit", EventUUID: "e-" + id, Input: input}
+	}
+	created := func(tc transcript.ToolCall, result string) transcript.ToolCall {
+		tc.Result = result
+		return tc
+	}
+
+	tests := []struct {
+		name  string
+		calls []transcript.ToolCall
+		want  []string // violation messages
+	}{
+		{
+			name: "one worktree per agent",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main && git worktree add ../myrepo-AGENTS-2 -b AGENTS-2 main"),
+				taskCall("t2", "e2", "cd ../myrepo-AGENTS-1 and implement AGENTS-1"),
+				taskCall("t3", "e3", "cd ../myrepo-AGENTS-2 and implement AGENTS-2"),
+			},
+		},
+		{
+			name: "subagent edits files in its worktree",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				taskCall("t2", "e2", "Implement AGENTS-1"),
+				subagentCall("t2", edit("t3", "/src/myrepo-AGENTS-1/main.go")),
+			},
+		},
+		{
+			name: "spawned from inside the worktree",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				bashCall("t2", "e2", "cd ../myrepo-AGENTS-1"),
+				taskCall("t3", "e3", "Implement AGENTS-1"),
+				subagentCall("t3", bashCall("t4", "e4", "go test ./...")),
+			},
+		},
+		{
+			name: "subagent works in the main checkout",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				taskCall("t2", "e2", "Implement AGENTS-1"),
+				subagentCall("t2", edit("t3", "/src/myrepo/main.go")),
+			},
+			want: []string{"Subagent doesn't work in a worktree; cd into its worktree in the Task prompt or the subagent's commands"},
+		},
+		{
+			name: "subagents share a worktree",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				taskCall("t2", "e2", "Implement AGENTS-1 in ../myrepo-AGENTS-1"),
+				taskCall("t3", "e3", "Write docs for AGENTS-1"),
+				subagentCall("t3", edit("t4", "/src/myrepo-AGENTS-1/README.md")),
+			},
+			want: []string{"Subagent shares worktree ../myrepo-AGENTS-1 with general-purpose (t2); give each parallel agent its own worktree"},
+		},
+		{
+			name: "removed after merge",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				created(bashCall("t2", "e2", "cd ../myrepo-AGENTS-1 && gh pr create --fill"), "https://github.com/o/r/pull/7"),
+				bashCall("t3", "e3", "cd /src/myrepo && gh pr merge 7 --merge"),
+				bashCall("t4", "e4", "git worktree remove ../myrepo-AGENTS-1"),
+			},
+		},
+		{
+			name: "not removed after merging from the worktree",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				created(bashCall("t2", "e2", "cd ../myrepo-AGENTS-1 && gh pr create --fill"), "https://github.com/o/r/pull/7"),
+				bashCall("t3", "e3", "cd /src/myrepo && gh pr merge 7 --merge"),
+			},
+			want: []string{"Worktree ../myrepo-AGENTS-1 was not removed after its PR merged; run `git worktree remove ../myrepo-AGENTS-1`"},
+		},
+		{
+			name: "not removed after PR merged",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				created(bashCall("t2", "e2", "gh pr create --head AGENTS-1 --fill"), "https://github.com/o/r/pull/7"),
+				bashCall("t3", "e3", "gh pr merge https://github.com/o/r/pull/7 --merge"),
+			},
+			want: []string{"Worktree ../myrepo-AGENTS-1 was not removed after its PR merged; run `git worktree remove ../myrepo-AGENTS-1`"},
+		},
+		{
+			name: "not removed after local merge",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				bashCall("t2", "e2", "git merge AGENTS-1"),
+			},
+			want: []string{"Worktree ../myrepo-AGENTS-1 was not removed after its PR merged; run `git worktree remove ../myrepo-AGENTS-1`"},
+		},
+		{
+			name: "forced removal",
+			calls: []transcript.ToolCall{
+				bashCall("t1", "e1", "git worktree add ../myrepo-AGENTS-1 -b AGENTS-1 main"),
+				bashCall("t2", "e2", "git worktree remove --force ../myrepo-AGENTS-1"),
+			},
+			want: []string{"Worktree ../myrepo-AGENTS-1 removed with --force, discarding its uncommitted changes; commit them before removing it"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ParallelWorktree{}
+			var got []string
+			for _, v := range c.Check(&transcript.Transcript{CWD: "/src/myrepo", ToolCalls: tt.calls}) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
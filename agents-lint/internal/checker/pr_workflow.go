@@ -0,0 +1,292 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&PRWorkflow{})
+}
+
+// PRWorkflow checks that completed work goes through a pull request that
+// the user reviews and merges.
+// Per AGENTS.md Rule 3: "ALWAYS open a PR, ensure all checks pass, and ask
+// the user to review it before merging."
+type PRWorkflow struct{}
+
+func (c *PRWorkflow) ID() string {
+	return "pr-workflow"
+}
+
+func (c *PRWorkflow) Description() string {
+	return "Ensures completed work is pushed, closed, opened as a PR, checked, and left for review (Rule 3)"
+}
+
+// prStep is a step of the completion workflow, in the required order.
+type prStep int
+
+const (
+	stepCommit prStep = iota
+	stepPush
+	stepClose
+	stepCreate
+	stepChecks
+	stepReview
+)
+
+// prSteps describes each step and the steps that must come before it.
+var prSteps = [...]struct {
+	did      string // for out-of-order messages
+	doing    string // for missing-step messages
+	requires []prStep
+}{
+	stepCommit: {"Committed the work", "committing the work", nil},
+	stepPush:   {"Pushed the feature branch", "pushing the feature branch", []prStep{stepCommit}},
+	stepClose:  {"Closed the bead", "closing the bead with bd close", []prStep{stepPush}},
+	stepCreate: {"Opened a PR", "opening a PR with gh pr create", []prStep{stepPush, stepClose}},
+	stepChecks: {"Monitored PR checks", "monitoring PR checks with gh pr checks", []prStep{stepCreate}},
+	stepReview: {"Asked the user to review the PR", "asking the user to review the PR", []prStep{stepChecks}},
+}
+
+var (
+	// reviewRequestPattern matches asking the user to review a PR.
+	reviewRequestPattern = regexp.MustCompile(`(?i)\breview\b.*\b(PR|pull request)\b|\b(PR|pull request)\b.*\breview`)
+
+	// greenfieldPattern matches a user instruction to work in greenfield mode.
+	greenfieldPattern = regexp.MustCompile(`(?i)\bgreen\s*-?\s*field\b`)
+
+	// sentencePattern splits a message into sentences.
+	sentencePattern = regexp.MustCompile(`[.!?;\n]+`)
+)
+
+func (c *PRWorkflow) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	human := humanMessages(t)
+	assistant := assistantMessages(t)
+	positions := eventPositions(t)
+
+	done := make(map[prStep]bool)
+	reported := make(map[prStep]bool)  // missing steps already reported
+	var completed *transcript.ToolCall // first call of the completion phase
+	checksFailing := false
+
+	violation := func(tc transcript.ToolCall, severity Severity, message string, context map[string]string) {
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 3",
+			Severity:   severity,
+			Message:    message,
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Context:    context,
+		})
+	}
+
+	greenfield := func(i int) bool {
+		for _, msg := range human {
+			if msg.index < i && isGreenfield(msg.text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Greenfield mode merges without a PR, so only merges are checked
+	greenfieldSession := greenfield(len(t.Events))
+
+	// reach records a step, reporting it if earlier steps were skipped.
+	reach := func(step prStep, tc transcript.ToolCall, source string) {
+		if done[step] {
+			return
+		}
+		done[step] = true
+
+		var missing []string
+		for _, s := range prSteps[step].requires {
+			if !done[s] && !reported[s] {
+				reported[s] = true
+				missing = append(missing, prSteps[s].doing)
+			}
+		}
+		if len(missing) > 0 && !greenfieldSession {
+			violation(tc, SeverityWarning, prSteps[step].did+" before "+strings.Join(missing, " and "),
+				map[string]string{"command": truncate(source, 100)})
+		}
+	}
+
+	// reviewRequests records review requests in assistant messages up to
+	// event index i.
+	next := 0
+	reviewRequests := func(i int) {
+		for ; next < len(assistant) && assistant[next].index < i; next++ {
+			msg := assistant[next]
+			if !done[stepCreate] || !reviewRequestPattern.MatchString(msg.text) {
+				continue
+			}
+			if checksFailing && !done[stepReview] {
+				done[stepReview] = true
+				violations = append(violations, Violation{
+					CheckerID: c.ID(),
+					Rule:      "Rule 3",
+					Severity:  SeverityWarning,
+					Message:   "Asked the user to review the PR while its checks were failing",
+					EventUUID: msg.uuid,
+					Context:   map[string]string{"message": truncate(msg.text, 100)},
+				})
+				continue
+			}
+			reach(stepReview, transcript.ToolCall{EventUUID: msg.uuid}, msg.text)
+		}
+	}
+
+	replaySession(t, func(tc transcript.ToolCall, _ State, ops []GitOp) bool {
+		pos, ok := positions[tc.ID]
+		if !ok {
+			pos = len(t.Events)
+		}
+		reviewRequests(pos)
+
+		_, cmds, ok := bashCommands(tc)
+		if !ok || tc.IsError && !isChecks(cmds) {
+			return true
+		}
+
+		for _, cmd := range cmds {
+			if sub, _, ok := cmd.Subcommand("bd"); ok && sub == "close" {
+				reach(stepClose, tc, cmd.Source)
+				if completed == nil {
+					completed = &tc
+				}
+				continue
+			}
+
+			if sub, args, ok := cmd.Subcommand("gh"); ok && sub == "pr" && len(args) > 0 {
+				switch args[0] {
+				case "create":
+					reach(stepCreate, tc, cmd.Source)
+					if completed == nil {
+						completed = &tc
+					}
+				case "checks":
+					reach(stepChecks, tc, cmd.Source)
+					checksFailing = checksFailed(tc)
+				case "merge":
+					c.checkMerge(tc, cmd, args[1:], greenfield(pos), violation)
+				}
+				continue
+			}
+
+			// ops are in the same order as the git commands that made them
+			if len(ops) == 0 || ops[0].Command.Source != cmd.Source {
+				continue
+			}
+			op := ops[0]
+			ops = ops[1:]
+
+			switch op.Kind {
+			case GitOpCommit:
+				reach(stepCommit, tc, cmd.Source)
+			case GitOpPush:
+				if _, ok := protectedRef(op.Refs); !ok {
+					reach(stepPush, tc, cmd.Source)
+				}
+			case GitOpMerge:
+				if protectedBranches[op.Branch] {
+					_, args, _ := cmd.Subcommand("git")
+					c.checkMerge(tc, cmd, args, greenfield(pos), violation)
+				}
+			}
+		}
+		return true
+	})
+	reviewRequests(len(t.Events))
+
+	if completed == nil || greenfieldSession {
+		return violations
+	}
+
+	var missing []string
+	for i, s := range prSteps {
+		step := prStep(i)
+		if !done[step] && !reported[step] {
+			missing = append(missing, s.doing)
+		}
+		if step == stepChecks && checksFailing {
+			missing = append(missing, "fixing the failing PR checks")
+		}
+	}
+	if len(missing) > 0 {
+		violation(*completed, SeverityWarning, "Work completed without "+strings.Join(missing, ", "), nil)
+	}
+
+	return violations
+}
+
+// checkMerge reports merges into main made without the user's greenfield
+// instruction, and squash or rebase merges.
+func (c *PRWorkflow) checkMerge(tc transcript.ToolCall, cmd SimpleCommand, args []string, greenfield bool,
+	violation func(transcript.ToolCall, Severity, string, map[string]string)) {
+	context := map[string]string{"command": truncate(cmd.Source, 100)}
+
+	if !greenfield {
+		violation(tc, SeverityError, "Merged to main without a greenfield-mode instruction from the user; leave the PR for review", context)
+	}
+
+	squash := hasFlag(args, "--squash")
+	rebase := hasFlag(args, "--rebase")
+	if cmd.Program() == "gh" {
+		squash = squash || hasFlag(args, "-s")
+		rebase = rebase || hasFlag(args, "-r")
+	}
+	switch {
+	case squash:
+		violation(tc, SeverityError, "Squash merge; use a regular merge to preserve commit history", context)
+	case rebase:
+		violation(tc, SeverityError, "Rebase merge; use a regular merge to preserve commit history", context)
+	}
+}
+
+// isChecks reports whether any of the commands runs `gh pr checks`, which
+// exits non-zero when checks fail.
+func isChecks(cmds []SimpleCommand) bool {
+	for _, cmd := range cmds {
+		if sub, args, ok := cmd.Subcommand("gh"); ok && sub == "pr" && len(args) > 0 && args[0] == "checks" {
+			return true
+		}
+	}
+	return false
+}
+
+// isGreenfield reports whether a user message puts the project in
+// greenfield mode: a sentence mentioning greenfield that doesn't negate it,
+// unlike "This is not a greenfield project."
+func isGreenfield(text string) bool {
+	for _, sentence := range sentencePattern.Split(text, -1) {
+		if greenfieldPattern.MatchString(sentence) && !negationPattern.MatchString(sentence) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksFailed reports whether `gh pr checks` found failing checks, from
+// the status column of its tab-separated output or, without one, from its
+// exit status.
+func checksFailed(tc transcript.ToolCall) bool {
+	rows := false
+	for line := range strings.Lines(tc.Result) {
+		fields := strings.Split(strings.TrimRight(line, "\r\n"), "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		rows = true
+		if fields[1] == "fail" {
+			return true
+		}
+	}
+	return !rows && tc.IsError
+}
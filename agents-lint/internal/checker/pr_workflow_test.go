@@ -0,0 +1,218 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPRWorkflow_ID(t *testing.T) {
+	c := &PRWorkflow{}
+	if c.ID() != "pr-workflow" {
+		t.Errorf("ID() = %q, want %q", c.ID(), "pr-workflow")
+	}
+}
+
+func TestPRWorkflow_Check(t *testing.T) {
+	tests := []struct {
+		name  string
+		turns []any
+		want  []string // violation messages
+	}{
+		{
+			name: "full workflow",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser (AGENTS-42)"`),
+				bash("git push -u origin AGENTS-42"),
+				bash(`bd close AGENTS-42 -r "Parser complete"`),
+				bash(`gh pr create --fill`),
+				bash("gh pr checks --watch"),
+				say("All checks pass. Please review the PR: https://github.com/o/r/pull/7"),
+			},
+		},
+		{
+			name: "work in progress",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "WIP"`),
+				bash("git push -u origin AGENTS-42"),
+			},
+		},
+		{
+			name: "stopped after closing",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser" && git push -u origin AGENTS-42`),
+				bash(`bd close AGENTS-42 -r "Done"`),
+			},
+			want: []string{"Work completed without opening a PR with gh pr create, monitoring PR checks with gh pr checks, asking the user to review the PR"},
+		},
+		{
+			name: "PR before push",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser"`),
+				bash("gh pr create --fill"),
+				bash("git push -u origin AGENTS-42"),
+				bash(`bd close AGENTS-42 -r "Done"`),
+				bash("gh pr checks"),
+				say("Checks are green; PR is ready for your review."),
+			},
+			want: []string{"Opened a PR before pushing the feature branch and closing the bead with bd close"},
+		},
+		{
+			name: "close before push",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser"`),
+				bash(`bd close AGENTS-42 -r "Done"`),
+				bash("git push -u origin AGENTS-42"),
+				bash("gh pr create --fill"),
+				bash("gh pr checks"),
+				say("Checks are green; PR is ready for your review."),
+			},
+			want: []string{"Closed the bead before pushing the feature branch"},
+		},
+		{
+			name: "push before commit",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash("git push -u origin AGENTS-42"),
+				bash(`git commit -am "Add parser"`),
+				bash(`bd close AGENTS-42 -r "Done"`),
+				bash("gh pr create --fill"),
+				bash("gh pr checks"),
+				say("Checks are green; PR is ready for your review."),
+			},
+			want: []string{"Pushed the feature branch before committing the work"},
+		},
+		{
+			name: "review before checks",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser" && git push -u origin AGENTS-42 && bd close AGENTS-42 && gh pr create --fill`),
+				say("Please review the PR."),
+			},
+			want: []string{"Asked the user to review the PR before monitoring PR checks with gh pr checks"},
+		},
+		{
+			name: "self merge",
+			turns: []any{
+				bash("git checkout -b AGENTS-42"),
+				bash(`git commit -am "Add parser" && git push -u origin AGENTS-42 && bd close AGENTS-42 && gh pr create --fill`),
+				bash("gh pr checks --watch"),
+				say("Checks passed. Could you review the pull request?"),
+				bash("gh pr merge 7 --merge"),
+			},
+			want: []string{"Merged to main without a greenfield-mode instruction from the user; leave the PR for review"},
+		},
+		{
+			name: "greenfield local merge",
+			turns: []any{
+				userText("u1", "This is a greenfield project, merge to main when done."),
+				bash("git checkout -b AGENTS-1"),
+				bash(`git commit -am "Scaffold"`),
+				bash(`bd close AGENTS-1 -r "Done"`),
+				bash("git checkout main && git merge AGENTS-1"),
+			},
+		},
+		{
+			name: "negated greenfield",
+			turns: []any{
+				userText("u1", "This is not a greenfield project."),
+				bash("gh pr merge 3"),
+			},
+			want: []string{"Merged to main without a greenfield-mode instruction from the user; leave the PR for review"},
+		},
+		{
+			name: "greenfield squash merge",
+			turns: []any{
+				userText("u1", "Greenfield mode: merge your own PRs."),
+				bash("gh pr merge 7 --squash --delete-branch"),
+			},
+			want: []string{"Squash merge; use a regular merge to preserve commit history"},
+		},
+		{
+			name: "rebase merge",
+			turns: []any{
+				bash("gh pr merge -r 7"),
+			},
+			want: []string{
+				"Merged to main without a greenfield-mode instruction from the user; leave the PR for review",
+				"Rebase merge; use a regular merge to preserve commit history",
+			},
+		},
+		{
+			name: "local merge into main",
+			turns: []any{
+				bash("git checkout main"),
+				bash("git merge --squash AGENTS-3"),
+			},
+			want: []string{
+				"Merged to main without a greenfield-mode instruction from the user; leave the PR for review",
+				"Squash merge; use a regular merge to preserve commit history",
+			},
+		},
+		{
+			name: "merging main into feature branch",
+			turns: []any{
+				bash("git checkout AGENTS-3"),
+				bash("git merge main"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &PRWorkflow{}
+			var got []string
+			for _, v := range c.Check(conversation(tt.turns...)) {
+				got = append(got, v.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRWorkflow_FailingChecks(t *testing.T) {
+	tr := conversation(
+		bash("git checkout -b AGENTS-42"),
+		bash(`git commit -am "Add parser" && git push -u origin AGENTS-42 && bd close AGENTS-42 && gh pr create --fill`),
+		bash("gh pr checks"),
+		say("The PR is ready for review."),
+	)
+	tr.ToolCalls[2].IsError = true
+	tr.ToolCalls[2].Result = "lint\tfail\t12s\thttps://github.com/o/r/actions/runs/1"
+
+	c := &PRWorkflow{}
+	var got []string
+	for _, v := range c.Check(tr) {
+		got = append(got, v.Message)
+	}
+	want := []string{
+		"Asked the user to review the PR while its checks were failing",
+		"Work completed without fixing the failing PR checks",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("violations = %q, want %q", got, want)
+	}
+}
+
+func TestPRWorkflow_PassingChecksOutput(t *testing.T) {
+	tr := conversation(
+		bash("git checkout -b AGENTS-42"),
+		bash(`git commit -am "Add parser" && git push -u origin AGENTS-42 && bd close AGENTS-42 && gh pr create --fill`),
+		bash("gh pr checks"),
+		say("The PR is ready for review."),
+	)
+	tr.ToolCalls[2].Result = "All checks were successful\n0 failing, 2 successful, 0 skipped, and 0 pending checks\n\n" +
+		"build\tpass\t1m2s\thttps://github.com/o/r/actions/runs/1\n" +
+		"lint-fail-fast\tpass\t12s\thttps://github.com/o/r/actions/runs/2\n"
+
+	c := &PRWorkflow{}
+	if violations := c.Check(tr); len(violations) != 0 {
+		t.Errorf("expected no violations for passing checks, got %v", violations)
+	}
+}
@@ -70,17 +70,43 @@ func RunAll(t *transcript.Transcript) *Result {
 	return Run(t, checkers)
 }
 
-// Run executes the specified checkers against a transcript.
-func Run(t *transcript.Transcript, checkers []Checker) *Result {
+// Run executes the specified checkers against a transcript. Violations
+// covered by a suppression, either given or typed by the user in the
+// transcript (see IgnoreMarker), are reported in Result.Suppressed instead.
+func Run(t *transcript.Transcript, checkers []Checker, suppressions ...Suppression) *Result {
 	result := &Result{
 		CheckersRun: make([]string, 0, len(checkers)),
 		Violations:  make([]Violation, 0),
 	}
 
+	markers, problems := transcriptMarkers(t)
+	suppressions = append(markers, suppressions...)
+	sup := newSuppressor(t, suppressions)
+	positions := sourcePositions(t)
+
 	for _, c := range checkers {
 		result.CheckersRun = append(result.CheckersRun, c.ID())
-		violations := c.Check(t)
-		result.Violations = append(result.Violations, violations...)
+		for _, v := range c.Check(t) {
+			if v.Line == 0 {
+				pos, ok := positions[v.ToolCallID]
+				if !ok {
+					pos = positions[v.EventUUID]
+				}
+				v.Line, v.Offset = pos.line, pos.offset
+			}
+			if s, ok := sup.match(v); ok {
+				result.Suppressed = append(result.Suppressed, SuppressedViolation{Violation: v, Suppression: s})
+				continue
+			}
+			result.Violations = append(result.Violations, v)
+		}
+	}
+
+	// Ignore markers that suppressed nothing because of unknown checkers
+	for _, v := range problems {
+		pos := positions[v.EventUUID]
+		v.Line, v.Offset = pos.line, pos.offset
+		result.Violations = append(result.Violations, v)
 	}
 
 	return result
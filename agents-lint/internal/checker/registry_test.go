@@ -165,6 +165,33 @@ func TestRunByIDs(t *testing.T) {
 	}
 }
 
+func TestRun_SetsLine(t *testing.T) {
+	tr := &transcript.Transcript{
+		Events: []any{
+			transcript.AssistantEvent{Event: transcript.Event{UUID: "a1", Line: 2, Offset: 90}},
+			transcript.ResultEvent{Event: transcript.Event{UUID: "r1", Line: 5, Offset: 400}},
+		},
+		ToolCalls: []transcript.ToolCall{{ID: "t1", EventUUID: "a1", Line: 2, Offset: 90}},
+	}
+	c := &mockChecker{id: "lines", violations: []Violation{
+		{Message: "tool call", ToolCallID: "t1"},
+		{Message: "event", EventUUID: "r1"},
+		{Message: "session"},
+	}}
+
+	result := Run(tr, []Checker{c})
+
+	want := []struct {
+		line   int
+		offset int64
+	}{{2, 90}, {5, 400}, {0, 0}}
+	for i, v := range result.Violations {
+		if v.Line != want[i].line || v.Offset != want[i].offset {
+			t.Errorf("%s: position = %d:%d, want %d:%d", v.Message, v.Line, v.Offset, want[i].line, want[i].offset)
+		}
+	}
+}
+
 func TestResultHasErrors_NoErrors(t *testing.T) {
 	result := &Result{
 		Violations: []Violation{
@@ -0,0 +1,206 @@
+package checker
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func init() {
+	Register(&RmApproval{})
+}
+
+// RmApproval checks that files are only deleted with the user's approval.
+// Per AGENTS.md Rule 1: "rm commands require user approval."
+type RmApproval struct{}
+
+func (c *RmApproval) ID() string {
+	return "rm-approval"
+}
+
+func (c *RmApproval) Description() string {
+	return "Ensures rm commands are approved by the user (Rule 1)"
+}
+
+// deletionPattern matches messages about deleting files.
+var deletionPattern = regexp.MustCompile(`(?i)\b(rm|delete|deleting|deletion|remove|removing|clean\s*up)\b`)
+
+func (c *RmApproval) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	human := humanMessages(t)
+	assistant := assistantMessages(t)
+	positions := eventPositions(t)
+	denied := permissionDenied(t)
+
+	for agent := range t.AgentTree().All() {
+		for _, tc := range agent.ToolCalls {
+			input, cmds, ok := bashCommands(tc)
+			if !ok || denied[tc.ID] {
+				continue
+			}
+			deletion, ok := findDeletion(cmds)
+			if !ok {
+				continue
+			}
+
+			i, ok := positions[tc.ID]
+			if ok && rmApproved(human, assistant, deletion, i) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				CheckerID:  c.ID(),
+				Rule:       "Rule 1",
+				Severity:   SeverityError,
+				Message:    "Files deleted without user approval",
+				EventUUID:  tc.EventUUID,
+				ToolCallID: tc.ID,
+				Agent:      agent.Name(),
+				Context: map[string]string{
+					"command":  truncate(input.Command, 100),
+					"deletion": truncate(deletion.Source, 100),
+				},
+			})
+		}
+	}
+
+	return violations
+}
+
+// rmApproved reports whether the user approved a deletion before event
+// index i: either by asking for it in their most recent message, without
+// negating it, or by agreeing when the agent asked to delete. Either way
+// the message must name every target the command deletes.
+func rmApproved(human, assistant []message, deletion SimpleCommand, i int) bool {
+	targets := deletionTargets(deletion)
+
+	reply, ok := lastBefore(human, i)
+	if !ok {
+		return false
+	}
+	if deletionPattern.MatchString(reply.text) && !negationPattern.MatchString(reply.text) && namesTargets(reply.text, targets) {
+		return true
+	}
+
+	a, found := findApproval(assistant, human, []*regexp.Regexp{deletionPattern}, i)
+	if _, _, problem := approvalProblem(a, found); problem {
+		return false
+	}
+	return namesTargets(a.request.text, targets)
+}
+
+// findPatternFlags are find tests whose value selects the files deleted.
+var findPatternFlags = []string{"-name", "-iname", "-path", "-ipath", "-wholename", "-regex", "-iregex"}
+
+// deletionTargets returns what a deletion must be approved by name: the
+// paths rm deletes, as written, including globs and variables; the start
+// paths other than "." and the name patterns of find; or, for xargs and a
+// find with neither, the whole command.
+func deletionTargets(cmd SimpleCommand) []string {
+	words := cmd.Words[len(cmd.Words)-len(cmd.Argv()):]
+	var targets []string
+
+	switch filepath.Base(cmd.Program()) {
+	case "rm":
+		for _, w := range words[1:] {
+			if strings.HasPrefix(w.Value, "-") {
+				continue
+			}
+			targets = append(targets, deletionTarget(w))
+		}
+		return targets
+
+	case "find":
+		paths := true
+		for j := 1; j < len(words); j++ {
+			w := words[j]
+			if strings.HasPrefix(w.Value, "-") || w.Value == "(" || w.Value == "!" {
+				paths = false
+			}
+			switch {
+			case paths && w.Value != "." && w.Value != "./":
+				targets = append(targets, deletionTarget(w))
+			case slices.Contains(findPatternFlags, w.Value) && j+1 < len(words):
+				targets = append(targets, deletionTarget(words[j+1]))
+				j++
+			}
+		}
+		if len(targets) > 0 {
+			return targets
+		}
+	}
+	return []string{cmd.Source}
+}
+
+// deletionTarget returns a deleted path as it must be named: its value,
+// or as written if it has expansions.
+func deletionTarget(w Word) string {
+	if !w.Static {
+		return w.Raw
+	}
+	if target := strings.TrimRight(w.Value, "/"); target != "" {
+		return target
+	}
+	return w.Value
+}
+
+// namesTargets reports whether text names every target, in full or, for
+// static paths, by base name, as a separate word.
+func namesTargets(text string, targets []string) bool {
+	for _, target := range targets {
+		names := []string{target}
+		if base := filepath.Base(target); base != target && !strings.ContainsAny(target, "$*?[") {
+			names = append(names, base)
+		}
+		if !slices.ContainsFunc(names, func(name string) bool { return namesWord(text, name) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// namesWord reports whether word appears in text delimited by spaces,
+// quotes, brackets, or punctuation, optionally followed by a slash.
+func namesWord(text, word string) bool {
+	pattern := `(^|[\s'"` + "`" + `(\[])` + regexp.QuoteMeta(word) + `/?($|[\s'"` + "`" + `)\],.;:!?])`
+	return regexp.MustCompile(pattern).MatchString(text)
+}
+
+// xargsValueFlags are xargs options that take a separate value.
+var xargsValueFlags = []string{"-I", "-n", "-P", "-L", "-d", "-E", "-s", "-a", "--delimiter", "--max-args", "--max-procs", "--arg-file"}
+
+// findExecActions are find actions that run a command.
+var findExecActions = []string{"-exec", "-execdir", "-ok", "-okdir"}
+
+// findDeletion returns the first command that deletes files: rm, including
+// when run through xargs or find -exec, or find -delete.
+func findDeletion(cmds []SimpleCommand) (SimpleCommand, bool) {
+	for _, cmd := range cmds {
+		argv := cmd.Argv()
+		if len(argv) == 0 {
+			continue
+		}
+		switch filepath.Base(argv[0]) {
+		case "rm":
+			return cmd, true
+		case "xargs":
+			if pos := positionalArgs(argv[1:], xargsValueFlags...); len(pos) > 0 && filepath.Base(pos[0]) == "rm" {
+				return cmd, true
+			}
+		case "find":
+			if slices.Contains(argv, "-delete") {
+				return cmd, true
+			}
+			for i, arg := range argv[:len(argv)-1] {
+				if slices.Contains(findExecActions, arg) && filepath.Base(argv[i+1]) == "rm" {
+					return cmd, true
+				}
+			}
+		}
+	}
+	return SimpleCommand{}, false
+}
@@ -0,0 +1,526 @@
+package checker
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// SimpleCommand is a single command invocation within a Bash tool call.
+// A command line such as `cd x && git push origin "$BRANCH" | tee log` yields
+// one SimpleCommand per program run, including commands nested inside
+// subshells, loops, and command substitutions, in source order.
+type SimpleCommand struct {
+	// Words are the command's arguments, including the program name.
+	Words []Word
+
+	// Assigns are leading environment assignments (e.g. GIT_DIR=x git ...).
+	Assigns []Assign
+
+	// Redirects are the redirections applied to the command.
+	Redirects []Redirect
+
+	// Background is true for commands run with a trailing &.
+	Background bool
+
+	// Source is the command's text as written.
+	Source string
+
+	// Loop is the innermost loop whose condition or body runs the
+	// command, or nil.
+	Loop *Loop
+}
+
+// Loop is a while, until, or for loop within a command line.
+type Loop struct {
+	// Kind is "while", "until", or "for".
+	Kind string
+
+	// Cond are the commands of a while or until condition.
+	Cond []SimpleCommand
+
+	// Source is the loop's text as written.
+	Source string
+}
+
+// Infinite reports whether the loop only ends through break or exit, as
+// in `while true`, `while :`, and `until false`.
+func (l *Loop) Infinite() bool {
+	if len(l.Cond) != 1 || len(l.Cond[0].Argv()) != 1 {
+		return false
+	}
+	switch l.Cond[0].Program() {
+	case "true", ":":
+		return l.Kind == "while"
+	case "false":
+		return l.Kind == "until"
+	}
+	return false
+}
+
+// Word is a single shell word after quote removal.
+type Word struct {
+	// Value is the word with quotes removed. Expansions that cannot be
+	// resolved statically ($VAR, $(cmd), ...) keep their source text.
+	Value string
+
+	// Raw is the word exactly as written.
+	Raw string
+
+	// Static is true if Value contains no unresolved expansions.
+	Static bool
+
+	// Subst are the commands run by command substitutions within the word.
+	Subst []SimpleCommand
+}
+
+// Assign is an environment assignment preceding a command.
+type Assign struct {
+	Name  string
+	Value string
+}
+
+// Redirect is an I/O redirection such as `> out.log` or `<<EOF`.
+type Redirect struct {
+	// Op is the operator as written (">", ">>", "<<", "<<-", "<<<", ...).
+	Op string
+
+	// Target is the redirection target, or the heredoc delimiter.
+	Target string
+
+	// Heredoc is the here-document body, if any.
+	Heredoc string
+}
+
+// IsHeredoc reports whether the redirect is a here-document.
+func (r Redirect) IsHeredoc() bool {
+	return r.Op == "<<" || r.Op == "<<-"
+}
+
+// wrapperCommands run their arguments as a new command. The value is the
+// set of flags that consume a following argument.
+var wrapperCommands = map[string]map[string]bool{
+	"sudo":    {"-u": true, "-g": true, "-C": true, "-D": true},
+	"env":     {"-u": true, "-C": true, "-S": true},
+	"command": {},
+	"builtin": {},
+	"exec":    {"-a": true},
+	"nohup":   {},
+	"time":    {},
+	"nice":    {"-n": true},
+	"timeout": {"-s": true, "-k": true, "--signal": true, "--kill-after": true},
+}
+
+// Args returns the command's argument values, including the program name.
+func (c SimpleCommand) Args() []string {
+	args := make([]string, len(c.Words))
+	for i, w := range c.Words {
+		args[i] = w.Value
+	}
+	return args
+}
+
+// Argv returns the arguments of the command actually run, with wrapper
+// prefixes such as sudo, env, nohup, and timeout (and their options)
+// removed.
+func (c SimpleCommand) Argv() []string {
+	args := c.Args()
+	for len(args) > 0 {
+		flags, ok := wrapperCommands[filepath.Base(args[0])]
+		if !ok {
+			break
+		}
+		env := filepath.Base(args[0]) == "env"
+		timed := filepath.Base(args[0]) == "timeout" // a duration precedes the command
+		args = args[1:]
+		for len(args) > 0 {
+			arg := args[0]
+			switch {
+			case arg == "--":
+				args = args[1:]
+			case strings.HasPrefix(arg, "-"):
+				args = args[1:]
+				if flags[arg] && len(args) > 0 {
+					args = args[1:]
+				}
+				continue
+			case env && strings.Contains(arg, "="):
+				args = args[1:]
+				continue
+			case timed:
+				args = args[1:]
+				timed = false
+				continue
+			}
+			break
+		}
+	}
+	return args
+}
+
+// Program returns the base name of the program being run, or "" for
+// assignment-only commands.
+func (c SimpleCommand) Program() string {
+	argv := c.Argv()
+	if len(argv) == 0 {
+		return ""
+	}
+	return filepath.Base(argv[0])
+}
+
+// globalOptions lists, per program, the options that appear before the
+// subcommand and take a separate value.
+var globalOptions = map[string]map[string]bool{
+	"git":     {"-C": true, "-c": true, "--git-dir": true, "--work-tree": true, "--namespace": true},
+	"bd":      {"--db": true, "--actor": true},
+	"gh":      {"-R": true, "--repo": true},
+	"kubectl": {"-n": true, "--namespace": true, "--context": true, "--kubeconfig": true},
+}
+
+// Subcommand returns the subcommand and its arguments for programs such as
+// git, bd, and gh, skipping global options like `git -C dir`. It reports
+// false if the command does not run program or has no subcommand.
+func (c SimpleCommand) Subcommand(program string) (string, []string, bool) {
+	sub, words, ok := c.subcommandWords(program)
+	if !ok {
+		return "", nil, false
+	}
+	args := make([]string, len(words))
+	for i, w := range words {
+		args[i] = w.Value
+	}
+	return sub, args, true
+}
+
+// subcommandWords is Subcommand, returning the arguments as Words.
+func (c SimpleCommand) subcommandWords(program string) (string, []Word, bool) {
+	words := c.Words[len(c.Words)-len(c.Argv()):]
+	if len(words) == 0 || filepath.Base(words[0].Value) != program {
+		return "", nil, false
+	}
+	opts := globalOptions[program]
+	words = words[1:]
+	for len(words) > 0 && strings.HasPrefix(words[0].Value, "-") {
+		takesValue := opts[words[0].Value]
+		words = words[1:]
+		if takesValue && len(words) > 0 {
+			words = words[1:]
+		}
+	}
+	if len(words) == 0 {
+		return "", nil, false
+	}
+	return words[0].Value, words[1:], true
+}
+
+// isGit reports whether the command runs the given git subcommand.
+func (c SimpleCommand) isGit(sub string) bool {
+	got, _, ok := c.Subcommand("git")
+	return ok && got == sub
+}
+
+// anyGit reports whether any of the commands runs the given git subcommand.
+func anyGit(cmds []SimpleCommand, sub string) bool {
+	for _, cmd := range cmds {
+		if cmd.isGit(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue returns the value of an option given as `--long value`,
+// `--long=value`, or `-s value` (short may be empty).
+func flagValue(args []string, short, long string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, long+"="); ok {
+			return v, true
+		}
+		if (arg == long || (short != "" && arg == short)) && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// hasFlag reports whether any of the given flags appears before a `--`.
+// Single-letter flags also match inside combined short options like -fu.
+func hasFlag(args []string, flags ...string) bool {
+	return findFlag(args, true, flags)
+}
+
+// hasExactFlag is like hasFlag but doesn't look inside combined short
+// options, for programs whose short options take attached values, such as
+// kubectl's -owide.
+func hasExactFlag(args []string, flags ...string) bool {
+	return findFlag(args, false, flags)
+}
+
+// findFlag implements hasFlag and hasExactFlag.
+func findFlag(args []string, combined bool, flags []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		for _, f := range flags {
+			if arg == f || strings.HasPrefix(arg, f+"=") {
+				return true
+			}
+			if combined && len(f) == 2 && f[0] == '-' && len(arg) > 2 && arg[0] == '-' && arg[1] != '-' &&
+				strings.IndexByte(arg[1:], f[1]) >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// positionalArgs returns the arguments that are not options. Options listed
+// in valued consume the following argument.
+func positionalArgs(args []string, valued ...string) []string {
+	var pos []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(pos, args[i+1:]...)
+		}
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			if slices.Contains(valued, arg) {
+				i++
+			}
+			continue
+		}
+		pos = append(pos, arg)
+	}
+	return pos
+}
+
+// ParseShell parses a Bash command line into the simple commands it runs.
+// If the command is not valid Bash, it falls back to splitting on
+// whitespace so that checkers still see something reasonable.
+func ParseShell(command string) []SimpleCommand {
+	parser := syntax.NewParser(syntax.Variant(syntax.LangBash))
+	file, err := parser.Parse(strings.NewReader(command), "")
+	if err != nil {
+		return fallbackCommands(command)
+	}
+
+	return collectCommands(command, file, nil)
+}
+
+// collectCommands returns the simple commands within node in source
+// order, recording the innermost loop running each one.
+func collectCommands(src string, node syntax.Node, loop *Loop) []SimpleCommand {
+	var cmds []SimpleCommand
+	syntax.Walk(node, func(node syntax.Node) bool {
+		switch n := node.(type) {
+		case *syntax.WhileClause:
+			inner := &Loop{Kind: "while", Source: nodeSource(src, n)}
+			if n.Until {
+				inner.Kind = "until"
+			}
+			for _, stmt := range n.Cond {
+				inner.Cond = append(inner.Cond, collectCommands(src, stmt, inner)...)
+			}
+			cmds = append(cmds, inner.Cond...)
+			for _, stmt := range n.Do {
+				cmds = append(cmds, collectCommands(src, stmt, inner)...)
+			}
+			return false
+		case *syntax.ForClause:
+			inner := &Loop{Kind: "for", Source: nodeSource(src, n)}
+			cmds = append(cmds, collectCommands(src, n.Loop, loop)...)
+			for _, stmt := range n.Do {
+				cmds = append(cmds, collectCommands(src, stmt, inner)...)
+			}
+			return false
+		case *syntax.Stmt:
+			if call, ok := n.Cmd.(*syntax.CallExpr); ok {
+				cmd := newSimpleCommand(src, n, call)
+				cmd.Loop = loop
+				cmds = append(cmds, cmd)
+			}
+		}
+		return true
+	})
+	return cmds
+}
+
+// bashCommands parses the command of a Bash tool call.
+// It returns false if the tool call is not a Bash call.
+func bashCommands(tc transcript.ToolCall) (BashInput, []SimpleCommand, bool) {
+	if tc.Name != "Bash" {
+		return BashInput{}, nil, false
+	}
+	var input BashInput
+	if err := json.Unmarshal(tc.Input, &input); err != nil {
+		return BashInput{}, nil, false
+	}
+	return input, ParseShell(input.Command), true
+}
+
+// fallbackCommands splits an unparseable command line on whitespace,
+// treating each line as a separate command.
+func fallbackCommands(command string) []SimpleCommand {
+	var cmds []SimpleCommand
+	for _, line := range strings.Split(command, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		cmd := SimpleCommand{Source: line}
+		for _, f := range fields {
+			cmd.Words = append(cmd.Words, Word{Value: f, Raw: f})
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+func newSimpleCommand(src string, stmt *syntax.Stmt, call *syntax.CallExpr) SimpleCommand {
+	cmd := SimpleCommand{
+		Background: stmt.Background,
+		Source:     nodeSource(src, stmt),
+	}
+	for _, a := range call.Assigns {
+		assign := Assign{Name: a.Name.Value}
+		if a.Value != nil {
+			assign.Value, _ = wordValue(src, a.Value)
+		}
+		cmd.Assigns = append(cmd.Assigns, assign)
+	}
+	for _, w := range call.Args {
+		cmd.Words = append(cmd.Words, newWord(src, w))
+	}
+	for _, r := range stmt.Redirs {
+		redir := Redirect{Op: r.Op.String()}
+		if r.Word != nil {
+			redir.Target, _ = wordValue(src, r.Word)
+		}
+		if r.Hdoc != nil {
+			redir.Heredoc, _ = wordValue(src, r.Hdoc)
+		}
+		cmd.Redirects = append(cmd.Redirects, redir)
+	}
+	return cmd
+}
+
+func newWord(src string, w *syntax.Word) Word {
+	value, static := wordValue(src, w)
+	word := Word{
+		Value:  value,
+		Raw:    nodeSource(src, w),
+		Static: static,
+	}
+	syntax.Walk(w, func(node syntax.Node) bool {
+		if stmt, ok := node.(*syntax.Stmt); ok {
+			if call, ok := stmt.Cmd.(*syntax.CallExpr); ok {
+				word.Subst = append(word.Subst, newSimpleCommand(src, stmt, call))
+			}
+		}
+		return true
+	})
+	return word
+}
+
+// wordValue performs quote removal on a word. Expansions are kept as
+// written, and the second result reports whether there were none.
+func wordValue(src string, w *syntax.Word) (string, bool) {
+	var sb strings.Builder
+	static := writeParts(&sb, src, w.Parts, false)
+	return sb.String(), static
+}
+
+func writeParts(sb *strings.Builder, src string, parts []syntax.WordPart, quoted bool) bool {
+	static := true
+	for _, part := range parts {
+		switch p := part.(type) {
+		case *syntax.Lit:
+			if quoted {
+				sb.WriteString(unescapeDoubleQuoted(p.Value))
+			} else {
+				sb.WriteString(unescapeUnquoted(p.Value))
+			}
+		case *syntax.SglQuoted:
+			if p.Dollar {
+				sb.WriteString(unescapeANSIC(p.Value))
+			} else {
+				sb.WriteString(p.Value)
+			}
+		case *syntax.DblQuoted:
+			if !writeParts(sb, src, p.Parts, true) {
+				static = false
+			}
+		default:
+			// Parameter, arithmetic, and command expansions
+			sb.WriteString(nodeSource(src, part))
+			static = false
+		}
+	}
+	return static
+}
+
+// unescapeUnquoted removes backslash escapes outside of quotes.
+func unescapeUnquoted(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == '\n' {
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// unescapeDoubleQuoted removes the backslash escapes recognized inside double quotes.
+func unescapeDoubleQuoted(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case '$', '`', '"', '\\':
+				i++
+			case '\n':
+				i++
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// unescapeANSIC expands the common escapes of $'...' strings.
+func unescapeANSIC(s string) string {
+	replacer := strings.NewReplacer(
+		`\n`, "\n", `\t`, "\t", `\r`, "\r",
+		`\\`, `\`, `\'`, `'`, `\"`, `"`,
+	)
+	return replacer.Replace(s)
+}
+
+// nodeSource returns the source text spanned by a node.
+func nodeSource(src string, n syntax.Node) string {
+	start, end := int(n.Pos().Offset()), int(n.End().Offset())
+	if start < 0 || end > len(src) || start > end {
+		return ""
+	}
+	return src[start:end]
+}
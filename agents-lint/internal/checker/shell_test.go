@@ -0,0 +1,177 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseShell_SplitsCompoundCommands(t *testing.T) {
+	cmds := ParseShell(`cd repo && git add . ; git commit -m "Fix thing" | tee log || echo failed`)
+
+	var programs []string
+	for _, cmd := range cmds {
+		programs = append(programs, cmd.Program())
+	}
+	want := []string{"cd", "git", "git", "tee", "echo"}
+	if !reflect.DeepEqual(programs, want) {
+		t.Errorf("programs = %v, want %v", programs, want)
+	}
+
+	if got := cmds[2].Args(); !reflect.DeepEqual(got, []string{"git", "commit", "-m", "Fix thing"}) {
+		t.Errorf("commit args = %q", got)
+	}
+}
+
+func TestParseShell_Loops(t *testing.T) {
+	cmds := ParseShell(`echo start; while ! gh pr checks; do for i in 1 2; do sleep 5; done; done; echo done`)
+
+	var programs []string
+	for _, cmd := range cmds {
+		programs = append(programs, cmd.Program())
+	}
+	want := []string{"echo", "gh", "sleep", "echo"}
+	if !reflect.DeepEqual(programs, want) {
+		t.Fatalf("programs = %v, want %v", programs, want)
+	}
+
+	if cmds[0].Loop != nil || cmds[3].Loop != nil {
+		t.Error("commands outside loops have a Loop")
+	}
+	outer := cmds[1].Loop
+	if outer == nil || outer.Kind != "while" || len(outer.Cond) != 1 || outer.Cond[0].Program() != "gh" {
+		t.Fatalf("condition loop = %+v, want the while loop", outer)
+	}
+	if inner := cmds[2].Loop; inner == nil || inner.Kind != "for" || inner == outer {
+		t.Errorf("sleep loop = %+v, want the inner for loop", inner)
+	}
+}
+
+func TestLoop_Infinite(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"while true; do sleep 5; done", true},
+		{"while :; do sleep 5; done", true},
+		{"until false; do sleep 5; done", true},
+		{"while ! gh pr checks; do sleep 5; done", false},
+		{"until true; do sleep 5; done", false},
+		{"while true && ok; do sleep 5; done", false},
+		{"for i in 1 2 3; do sleep 5; done", false},
+	}
+
+	for _, tt := range tests {
+		cmds := ParseShell(tt.command)
+		loop := cmds[len(cmds)-1].Loop
+		if loop == nil {
+			t.Fatalf("%q: sleep has no loop", tt.command)
+		}
+		if got := loop.Infinite(); got != tt.want {
+			t.Errorf("%q: Infinite() = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestParseShell_QuotedStringsAreNotCommands(t *testing.T) {
+	cmds := ParseShell(`echo "remember to git commit" && grep 'git push origin main' notes.txt`)
+
+	for _, cmd := range cmds {
+		if _, _, ok := cmd.Subcommand("git"); ok {
+			t.Errorf("quoted text parsed as git command: %q", cmd.Source)
+		}
+	}
+}
+
+func TestParseShell_Words(t *testing.T) {
+	cmds := ParseShell(`git push origin "$BRANCH" $'a\nb' "it's \"quoted\"" plain\ word`)
+	if len(cmds) != 1 {
+		t.Fatalf("len(cmds) = %d, want 1", len(cmds))
+	}
+
+	words := cmds[0].Words
+	if words[3].Value != "$BRANCH" || words[3].Static {
+		t.Errorf("variable word = %+v, want unresolved $BRANCH", words[3])
+	}
+	if words[4].Value != "a\nb" {
+		t.Errorf("ANSI-C word = %q, want %q", words[4].Value, "a\nb")
+	}
+	if words[5].Value != `it's "quoted"` || !words[5].Static {
+		t.Errorf("double-quoted word = %+v", words[5])
+	}
+	if words[6].Value != "plain word" {
+		t.Errorf("escaped word = %q, want %q", words[6].Value, "plain word")
+	}
+}
+
+func TestParseShell_CommandSubstitution(t *testing.T) {
+	cmds := ParseShell(`git commit -m "$(cat <<'EOF'
+Title
+
+Body
+EOF
+)"`)
+
+	if len(cmds) != 2 {
+		t.Fatalf("len(cmds) = %d, want 2 (git and nested cat)", len(cmds))
+	}
+
+	msg := cmds[0].Words[3]
+	if msg.Static {
+		t.Error("command substitution word should not be static")
+	}
+	if len(msg.Subst) != 1 || msg.Subst[0].Program() != "cat" {
+		t.Fatalf("Subst = %+v, want nested cat", msg.Subst)
+	}
+	redirs := msg.Subst[0].Redirects
+	if len(redirs) != 1 || !redirs[0].IsHeredoc() || redirs[0].Heredoc != "Title\n\nBody\n" {
+		t.Errorf("Redirects = %+v, want heredoc body", redirs)
+	}
+}
+
+func TestParseShell_AssignsAndRedirects(t *testing.T) {
+	cmds := ParseShell(`GIT_DIR=.git git status > out.log 2>&1 &`)
+	if len(cmds) != 1 {
+		t.Fatalf("len(cmds) = %d, want 1", len(cmds))
+	}
+
+	cmd := cmds[0]
+	if len(cmd.Assigns) != 1 || cmd.Assigns[0] != (Assign{Name: "GIT_DIR", Value: ".git"}) {
+		t.Errorf("Assigns = %+v", cmd.Assigns)
+	}
+	if len(cmd.Redirects) != 2 || cmd.Redirects[0].Op != ">" || cmd.Redirects[0].Target != "out.log" {
+		t.Errorf("Redirects = %+v", cmd.Redirects)
+	}
+	if !cmd.Background {
+		t.Error("Background = false, want true")
+	}
+}
+
+func TestSimpleCommand_Subcommand(t *testing.T) {
+	tests := []struct {
+		command string
+		program string
+		sub     string
+		args    []string
+	}{
+		{"git -C ../repo commit -m x", "git", "commit", []string{"-m", "x"}},
+		{"sudo -u me git --no-pager log", "git", "log", []string{}},
+		{"env FOO=1 bd --db x.db update AGENTS-1 --status in_progress", "bd", "update", []string{"AGENTS-1", "--status", "in_progress"}},
+		{"/usr/bin/git push", "git", "push", []string{}},
+		{"timeout -k 5 2m gh pr checks --watch", "gh", "pr", []string{"checks", "--watch"}},
+	}
+
+	for _, tt := range tests {
+		cmds := ParseShell(tt.command)
+		sub, args, ok := cmds[0].Subcommand(tt.program)
+		if !ok || sub != tt.sub || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%q: Subcommand = (%q, %q, %v), want (%q, %q)", tt.command, sub, args, ok, tt.sub, tt.args)
+		}
+	}
+}
+
+func TestParseShell_FallbackOnSyntaxError(t *testing.T) {
+	cmds := ParseShell(`git commit -m "unterminated`)
+	if len(cmds) != 1 || cmds[0].Program() != "git" {
+		t.Errorf("fallback commands = %+v, want single git command", cmds)
+	}
+}
@@ -1,8 +1,6 @@
 package checker
 
 import (
-	"encoding/json"
-	"regexp"
 	"strings"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
@@ -26,114 +24,126 @@ func (c *SingleLineCommit) Description() string {
 
 // BashInput represents the input structure for Bash tool calls.
 type BashInput struct {
-	Command string `json:"command"`
+	Command         string `json:"command"`
+	RunInBackground bool   `json:"run_in_background"`
 }
 
-// heredocPattern matches heredoc patterns in commit commands
-var heredocPattern = regexp.MustCompile(`<<['"]?EOF['"]?|<<-['"]?EOF['"]?|\$\(cat <<`)
-
-// multilineMessagePattern matches -m with content containing newlines
-var gitCommitPattern = regexp.MustCompile(`git\s+commit`)
-
 func (c *SingleLineCommit) Check(t *transcript.Transcript) []Violation {
 	var violations []Violation
 
 	for _, tc := range t.ToolCalls {
-		if tc.Name != "Bash" {
-			continue
-		}
-
-		// Parse the Bash input
-		var input BashInput
-		if err := json.Unmarshal(tc.Input, &input); err != nil {
-			continue
-		}
-
-		// Check if this is a git commit command
-		if !gitCommitPattern.MatchString(input.Command) {
+		input, cmds, ok := bashCommands(tc)
+		if !ok {
 			continue
 		}
 
-		// Check for heredoc pattern (violation)
-		if heredocPattern.MatchString(input.Command) {
-			violations = append(violations, Violation{
-				CheckerID:  c.ID(),
-				Rule:       "Commit Message Format",
-				Severity:   SeverityError,
-				Message:    "Git commit uses heredoc format; use single-line -m \"message\" instead",
-				EventUUID:  tc.EventUUID,
-				ToolCallID: tc.ID,
-				Context: map[string]string{
-					"command": truncate(input.Command, 100),
-				},
-			})
-			continue
-		}
+		for _, cmd := range cmds {
+			sub, args, ok := cmd.subcommandWords("git")
+			if !ok || sub != "commit" {
+				continue
+			}
+
+			var message string
+			switch {
+			case commitUsesHeredoc(cmd, args):
+				message = "Git commit uses heredoc format; use single-line -m \"message\" instead"
+			case hasMultilineMessage(args):
+				message = "Git commit message contains newlines; use single-line format"
+			case len(commitMessages(args)) > 1:
+				message = "Git commit uses multiple -m flags, which creates a multi-line message; use a single -m"
+			default:
+				continue
+			}
 
-		// Check for multi-line message (newlines in the -m argument)
-		if hasMultilineMessage(input.Command) {
 			violations = append(violations, Violation{
 				CheckerID:  c.ID(),
 				Rule:       "Commit Message Format",
 				Severity:   SeverityError,
-				Message:    "Git commit message contains newlines; use single-line format",
+				Message:    message,
 				EventUUID:  tc.EventUUID,
 				ToolCallID: tc.ID,
 				Context: map[string]string{
 					"command": truncate(input.Command, 100),
 				},
 			})
+			break
 		}
 	}
 
 	return violations
 }
 
-// hasMultilineMessage checks if a git commit command has a multi-line -m message.
-func hasMultilineMessage(cmd string) bool {
-	// Find -m followed by quoted string
-	// Look for newlines within the message
-	idx := strings.Index(cmd, "-m ")
-	if idx == -1 {
-		idx = strings.Index(cmd, "-m\"")
-		if idx == -1 {
-			return false
+// commitValueFlags are git commit short options that take a value.
+const commitValueFlags = "mFCct"
+
+// commitMessages returns the words given to -m/--message in git commit arguments.
+func commitMessages(args []Word) []Word {
+	var msgs []Word
+	for i := 0; i < len(args); i++ {
+		arg := args[i].Value
+		switch {
+		case arg == "--":
+			return msgs
+		case arg == "--message":
+			if i+1 < len(args) {
+				msgs = append(msgs, args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "--message="):
+			w := args[i]
+			w.Value = strings.TrimPrefix(arg, "--message=")
+			msgs = append(msgs, w)
+		case len(arg) > 1 && arg[0] == '-' && arg[1] != '-':
+			// Combined short options such as -am "msg" or -m"msg"
+			for j := 1; j < len(arg); j++ {
+				if !strings.ContainsRune(commitValueFlags, rune(arg[j])) {
+					continue
+				}
+				var value Word
+				if j+1 < len(arg) {
+					value = args[i]
+					value.Value = arg[j+1:]
+				} else if i+1 < len(args) {
+					i++
+					value = args[i]
+				}
+				if arg[j] == 'm' {
+					msgs = append(msgs, value)
+				}
+				break
+			}
 		}
 	}
+	return msgs
+}
 
-	// Get the part after -m
-	rest := cmd[idx+2:]
-	rest = strings.TrimLeft(rest, " ")
-
-	if len(rest) == 0 {
-		return false
+// commitUsesHeredoc checks if a git commit reads its message from a heredoc,
+// either directly (-F - <<EOF) or via -m "$(cat <<EOF ... EOF)".
+func commitUsesHeredoc(cmd SimpleCommand, args []Word) bool {
+	for _, r := range cmd.Redirects {
+		if r.IsHeredoc() {
+			return true
+		}
 	}
-
-	// Check for actual newlines in the message portion
-	// Look for the quoted message
-	quote := rest[0]
-	if quote != '"' && quote != '\'' {
-		return false
+	for _, msg := range commitMessages(args) {
+		for _, sub := range msg.Subst {
+			for _, r := range sub.Redirects {
+				if r.IsHeredoc() {
+					return true
+				}
+			}
+		}
 	}
+	return false
+}
 
-	// Find the closing quote, accounting for escapes
-	inEscape := false
-	for i := 1; i < len(rest); i++ {
-		if inEscape {
-			inEscape = false
-			continue
-		}
-		if rest[i] == '\\' {
-			inEscape = true
-			continue
-		}
-		if rest[i] == byte(quote) {
-			// Found closing quote, check if message had newlines
-			message := rest[1:i]
-			return strings.Contains(message, "\n")
+// hasMultilineMessage checks if any -m message contains a newline.
+func hasMultilineMessage(args []Word) bool {
+	for _, msg := range commitMessages(args) {
+		if strings.Contains(msg.Value, "\n") {
+			return true
 		}
 	}
-
 	return false
 }
 
@@ -157,3 +157,38 @@ func TestSingleLineCommit_Registered(t *testing.T) {
 		t.Error("single-line-commit checker not registered")
 	}
 }
+
+func TestSingleLineCommit_ArgumentAware(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		violations int
+	}{
+		{"multiple -m flags", `git commit -m "Title" -m "Body"`, 1},
+		{"combined -am", "git commit -am \"Line 1\nLine 2\"", 1},
+		{"ANSI-C newline", `git commit -m $'Line 1\nLine 2'`, 1},
+		{"heredoc via -F", "git commit -F - <<EOF\nmessage\nEOF", 1},
+		{"global options", "git -C ../repo commit -m \"Line 1\nLine 2\"", 1},
+		{"escaped newline is literal", `git commit -m "Fix \n handling"`, 0},
+		{"quoted commit in echo", "echo \"git commit -m 'a\nb'\"", 0},
+		{"newline outside message", "git add .\ngit commit -m \"Update\"", 0},
+	}
+
+	c := &SingleLineCommit{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := json.Marshal(BashInput{Command: tt.command})
+			tr := &transcript.Transcript{
+				ToolCalls: []transcript.ToolCall{
+					{ID: "t1", Name: "Bash", Input: input},
+				},
+			}
+
+			violations := c.Check(tr)
+			if len(violations) != tt.violations {
+				t.Errorf("expected %d violations for %q, got %d: %v", tt.violations, tt.command, len(violations), violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package checker
+
+import (
+	"iter"
+	"path/filepath"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// State is where an agent is working when it makes a tool call.
+type State struct {
+	// CWD is the working directory ("" if unknown). Relative paths are
+	// resolved against Transcript.CWD, so it stays relative when that is unknown.
+	CWD string
+
+	// Branch is the branch checked out in CWD ("" if unknown).
+	Branch string
+
+	// Worktree is the linked worktree containing CWD, as created by
+	// `git worktree add` ("" for the main checkout).
+	Worktree string
+}
+
+// States replays the session's Bash calls starting from Transcript.CWD and
+// yields each tool call with the state it ran in. Each agent has its own
+// working directory, inherited from the agent that spawned it.
+func States(t *transcript.Transcript) iter.Seq2[transcript.ToolCall, State] {
+	return func(yield func(transcript.ToolCall, State) bool) {
+		replaySession(t, func(tc transcript.ToolCall, before State, _ []GitOp) bool {
+			return yield(tc, before)
+		})
+	}
+}
+
+// sessionReplay tracks working directories per agent and branches per
+// worktree while replaying a session.
+type sessionReplay struct {
+	root      string
+	cwd       map[string]string   // agent ID ("" for main) -> working directory
+	oldpwd    map[string]string   // agent ID -> directory before the last cd
+	dirs      map[string][]string // agent ID -> pushd stack
+	worktrees map[string]bool     // linked worktree paths
+	branch    map[string]string   // worktree path (root for main) -> branch
+	previous  map[string]string   // worktree path -> branch before the last checkout
+}
+
+// replaySession replays the Bash calls of a session in order, calling fn
+// with each tool call, the state before it ran, and the git operations it
+// performed. Replay stops if fn returns false.
+func replaySession(t *transcript.Transcript, fn func(transcript.ToolCall, State, []GitOp) bool) {
+	r := &sessionReplay{
+		root:      t.CWD,
+		cwd:       map[string]string{"": t.CWD},
+		oldpwd:    make(map[string]string),
+		dirs:      make(map[string][]string),
+		worktrees: make(map[string]bool),
+		branch:    make(map[string]string),
+		previous:  make(map[string]string),
+	}
+	recorded := recordedStates(t)
+
+	for _, tc := range t.ToolCalls {
+		agent := tc.ParentToolUseID
+		if _, ok := r.cwd[agent]; !ok {
+			r.cwd[agent] = t.CWD
+		}
+
+		// Session logs record the actual cwd and branch of each message
+		if rec, ok := recorded[tc.EventUUID]; ok {
+			if rec.CWD != "" {
+				r.cwd[agent] = rec.CWD
+			}
+			if rec.Branch != "" {
+				r.branch[r.worktreeOf(r.cwd[agent])] = rec.Branch
+			}
+		}
+
+		before := r.state(agent)
+		if tc.Name == transcript.TaskTool {
+			r.cwd[tc.ID] = before.CWD
+		}
+
+		var ops []GitOp
+		if _, cmds, ok := bashCommands(tc); ok {
+			for _, cmd := range cmds {
+				if op, ok := r.apply(agent, cmd, tc.IsError); ok {
+					op.ToolCall = tc
+					ops = append(ops, op)
+				}
+			}
+			if b := reportedBranch(cmds, tc.Result); b != "" {
+				r.branch[r.worktreeOf(r.cwd[agent])] = b
+			}
+		}
+
+		if !fn(tc, before, ops) {
+			return
+		}
+	}
+}
+
+// recordedStates maps event UUIDs to the cwd and gitBranch that session
+// logs record on each assistant message.
+func recordedStates(t *transcript.Transcript) map[string]State {
+	states := make(map[string]State)
+	for _, event := range t.Events {
+		if ev, ok := event.(transcript.AssistantEvent); ok && (ev.CWD != "" || ev.GitBranch != "") {
+			states[ev.UUID] = State{CWD: ev.CWD, Branch: ev.GitBranch}
+		}
+	}
+	return states
+}
+
+// state returns the current state of an agent.
+func (r *sessionReplay) state(agent string) State {
+	cwd := r.cwd[agent]
+	wt := r.worktreeOf(cwd)
+	s := State{CWD: cwd, Branch: r.branch[wt]}
+	if wt != r.root {
+		s.Worktree = wt
+	}
+	return s
+}
+
+// worktreeOf returns the linked worktree containing dir, or the root.
+func (r *sessionReplay) worktreeOf(dir string) string {
+	best := r.root
+	for wt := range r.worktrees {
+		if (dir == wt || strings.HasPrefix(dir, wt+"/")) && (best == r.root || len(wt) > len(best)) {
+			best = wt
+		}
+	}
+	return best
+}
+
+// apply updates the replay state for one command and interprets it as a
+// git operation. Git state only changes if the tool call succeeded.
+func (r *sessionReplay) apply(agent string, cmd SimpleCommand, failed bool) (GitOp, bool) {
+	switch argv := cmd.Argv(); cmd.Program() {
+	case "cd":
+		r.oldpwd[agent], r.cwd[agent] = r.cwd[agent], chdir(r.cwd[agent], r.oldpwd[agent], argv[1:])
+		return GitOp{}, false
+	case "pushd":
+		r.dirs[agent] = append(r.dirs[agent], r.cwd[agent])
+		r.cwd[agent] = chdir(r.cwd[agent], r.oldpwd[agent], argv[1:])
+		return GitOp{}, false
+	case "popd":
+		if stack := r.dirs[agent]; len(stack) > 0 {
+			r.cwd[agent], r.dirs[agent] = stack[len(stack)-1], stack[:len(stack)-1]
+		}
+		return GitOp{}, false
+	}
+
+	dir := r.cwd[agent]
+	for _, d := range gitDirs(cmd) {
+		dir = resolvePath(dir, d)
+	}
+	wt := r.worktreeOf(dir)
+
+	op, ok := parseGitOp(cmd, r.branch[wt], r.previous[wt])
+	if !ok {
+		return GitOp{}, false
+	}
+	op.Dir = dir
+	if op.Path != "" {
+		op.Path = resolvePath(dir, op.Path)
+	}
+	if failed {
+		return op, true
+	}
+
+	switch op.Kind {
+	case GitOpCheckout:
+		if op.Target != "" {
+			r.previous[wt], r.branch[wt] = r.branch[wt], op.Target
+		}
+	case GitOpWorktreeAdd:
+		r.worktrees[op.Path] = true
+		r.branch[op.Path] = op.Target
+	case GitOpWorktreeRemove:
+		delete(r.worktrees, op.Path)
+		delete(r.branch, op.Path)
+	}
+	return op, true
+}
+
+// chdir returns the directory `cd args` moves to from dir. Targets that
+// can't be resolved statically make the directory unknown.
+func chdir(dir, oldpwd string, args []string) string {
+	pos := positionalArgs(args)
+	switch {
+	case len(pos) == 0:
+		return "~"
+	case pos[0] == "-":
+		return oldpwd
+	case strings.ContainsAny(pos[0], "$`*?"), strings.HasPrefix(pos[0], "~") && !isHomePath(pos[0]):
+		// ~user is another user's home directory
+		return ""
+	}
+	return resolvePath(dir, pos[0])
+}
+
+// isHomePath reports whether path is relative to the home directory, which
+// is tracked as "~" since its location isn't known.
+func isHomePath(path string) bool {
+	return path == "~" || strings.HasPrefix(path, "~/")
+}
+
+// resolvePath resolves path relative to dir.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) || isHomePath(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
+
+// gitDirs returns the directories given by `git -C dir` global options.
+func gitDirs(cmd SimpleCommand) []string {
+	argv := cmd.Argv()
+	if len(argv) == 0 || filepath.Base(argv[0]) != "git" {
+		return nil
+	}
+	var dirs []string
+	for i := 1; i < len(argv) && strings.HasPrefix(argv[i], "-"); i++ {
+		if argv[i] == "-C" && i+1 < len(argv) {
+			dirs = append(dirs, argv[i+1])
+		}
+		if globalOptions["git"][argv[i]] {
+			i++
+		}
+	}
+	return dirs
+}
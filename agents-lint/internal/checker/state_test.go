@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func collectStates(tr *transcript.Transcript) map[string]State {
+	states := make(map[string]State)
+	for tc, s := range States(tr) {
+		states[tc.ID] = s
+	}
+	return states
+}
+
+func TestStates_WorkingDirectory(t *testing.T) {
+	tr := bashTranscript(
+		"cd internal && ls",
+		"cd ../cmd",
+		"cd -",
+		"pushd /tmp && popd",
+		`cd "$DIR"`,
+		"pwd",
+	)
+	tr.CWD = "/repo"
+
+	states := collectStates(tr)
+	want := map[string]string{
+		"t1": "/repo",
+		"t2": "/repo/internal",
+		"t3": "/repo/cmd",
+		"t4": "/repo/internal",
+		"t5": "/repo/internal",
+		"t6": "",
+	}
+	for id, cwd := range want {
+		if states[id].CWD != cwd {
+			t.Errorf("%s: CWD = %q, want %q", id, states[id].CWD, cwd)
+		}
+	}
+}
+
+func TestStates_HomeDirectory(t *testing.T) {
+	tr := bashTranscript(
+		"cd ~/src/repo",
+		"cd ../other",
+		"cd ~",
+		"cd ~alice/src",
+		"cd /repo",
+	)
+	tr.CWD = "/repo"
+
+	states := collectStates(tr)
+	want := map[string]string{
+		"t2": "~/src/repo",
+		"t3": "~/src/other",
+		"t4": "~",
+		"t5": "",
+	}
+	for id, cwd := range want {
+		if states[id].CWD != cwd {
+			t.Errorf("%s: CWD = %q, want %q", id, states[id].CWD, cwd)
+		}
+	}
+}
+
+func TestStates_Worktrees(t *testing.T) {
+	tr := bashTranscript(
+		"git checkout main",
+		"git worktree add -b AGENTS-5 ../repo-AGENTS-5",
+		"cd ../repo-AGENTS-5/src",
+		"git commit -m 'Add thing'",
+		"cd /repo",
+		"git -C ../repo-AGENTS-5 push origin HEAD",
+	)
+	tr.CWD = "/repo"
+
+	states := collectStates(tr)
+	if s := states["t3"]; s.Branch != "main" || s.Worktree != "" {
+		t.Errorf("before cd: %+v, want main checkout on main", s)
+	}
+	if s := states["t4"]; s.Branch != "AGENTS-5" || s.Worktree != "/repo-AGENTS-5" || s.CWD != "/repo-AGENTS-5/src" {
+		t.Errorf("in worktree: %+v, want AGENTS-5 in /repo-AGENTS-5", s)
+	}
+	if s := states["t6"]; s.Branch != "main" || s.Worktree != "" {
+		t.Errorf("back in repo: %+v, want main checkout on main", s)
+	}
+
+	ops := GitOps(tr)
+	push := ops[len(ops)-1]
+	if push.Dir != "/repo-AGENTS-5" || push.Branch != "AGENTS-5" || push.Refs[0].Dst != "AGENTS-5" {
+		t.Errorf("git -C push = dir %q branch %q refs %+v, want AGENTS-5 worktree", push.Dir, push.Branch, push.Refs)
+	}
+}
+
+func TestStates_SubagentsHaveOwnDirectory(t *testing.T) {
+	tr := bashTranscript("cd pkg", "", "cd ../wt", "git status")
+	tr.CWD = "/repo"
+	tr.ToolCalls[1].Name = transcript.TaskTool
+	tr.ToolCalls[1].ID = "task-1"
+	tr.ToolCalls[2].ParentToolUseID = "task-1"
+
+	states := collectStates(tr)
+	if s := states["t3"]; s.CWD != "/repo/pkg" {
+		t.Errorf("subagent starts in %q, want spawner's /repo/pkg", s.CWD)
+	}
+	if s := states["t4"]; s.CWD != "/repo/pkg" {
+		t.Errorf("main agent moved to %q by subagent cd, want /repo/pkg", s.CWD)
+	}
+}
+
+func TestStates_SessionLogRecordedState(t *testing.T) {
+	tr := bashTranscript("git status")
+	tr.CWD = "/repo"
+	tr.ToolCalls[0].EventUUID = "u1"
+	tr.Events = []any{transcript.AssistantEvent{Event: transcript.Event{UUID: "u1", CWD: "/repo/sub", GitBranch: "AGENTS-1"}}}
+
+	s := collectStates(tr)["t1"]
+	if s.CWD != "/repo/sub" || s.Branch != "AGENTS-1" {
+		t.Errorf("state = %+v, want recorded cwd and branch", s)
+	}
+}
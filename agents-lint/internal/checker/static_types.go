@@ -16,7 +16,11 @@ func init() {
 // Per AGENTS.md Rule 9: "Prefer Static Types"
 // - New projects: Use Go, Kotlin, TypeScript, or Rust
 // - Scripting: Always use type hints (Python) or TypeScript (not JS)
-type StaticTypes struct{}
+type StaticTypes struct {
+	// ConfigFilePatterns are file name suffixes of JS config files that are
+	// allowed. Defaults to configFilePatterns if nil.
+	ConfigFilePatterns []string
+}
 
 func (c *StaticTypes) ID() string {
 	return "static-types"
@@ -26,6 +30,20 @@ func (c *StaticTypes) Description() string {
 	return "Ensures new code uses TypeScript instead of JavaScript (Rule 9)"
 }
 
+// Configure sets options from the project configuration file.
+func (c *StaticTypes) Configure(decode func(any) error) error {
+	var opts struct {
+		ConfigFilePatterns []string `yaml:"config_file_patterns"`
+	}
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	if opts.ConfigFilePatterns != nil {
+		c.ConfigFilePatterns = opts.ConfigFilePatterns
+	}
+	return nil
+}
+
 // configFilePatterns are JS files that are exceptions (config files typically require .js)
 var configFilePatterns = []string{
 	".config.js",
@@ -37,11 +55,16 @@ var configFilePatterns = []string{
 }
 
 // isConfigFile checks if a file path is a known config file that requires .js
-func isConfigFile(path string) bool {
+func (c *StaticTypes) isConfigFile(path string) bool {
 	base := filepath.Base(path)
 
+	patterns := c.ConfigFilePatterns
+	if patterns == nil {
+		patterns = configFilePatterns
+	}
+
 	// Check common config file patterns
-	for _, pattern := range configFilePatterns {
+	for _, pattern := range patterns {
 		if strings.HasSuffix(base, pattern) {
 			return true
 		}
@@ -79,7 +102,7 @@ func (c *StaticTypes) Check(t *transcript.Transcript) []Violation {
 		// Flag .js and .jsx files (should use .ts and .tsx)
 		if ext == ".js" || ext == ".jsx" {
 			// Skip config files which often require .js
-			if isConfigFile(input.FilePath) {
+			if c.isConfigFile(input.FilePath) {
 				continue
 			}
 
@@ -0,0 +1,168 @@
+package checker
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+// IgnoreMarker is the directive a user types in the transcript to suppress
+// violations, optionally followed by checker IDs and "-- reason".
+const IgnoreMarker = "agents-lint:ignore"
+
+// Suppression exempts matching violations from failing the check.
+type Suppression struct {
+	// SessionID limits the suppression to one session ("" or "*" for any).
+	SessionID string
+
+	// ID is the tool_use ID or event UUID of the suppressed violation.
+	// Empty for in-transcript markers, which apply from EventUUID onward.
+	ID string
+
+	// EventUUID is the user event containing an in-transcript marker.
+	EventUUID string
+
+	// CheckerIDs are the checkers suppressed (empty for all).
+	CheckerIDs []string
+
+	// Reason explains why the violation is acceptable.
+	Reason string
+
+	// Source describes where the suppression came from, such as
+	// ".agents-lint-ignore:3" or "transcript".
+	Source string
+}
+
+// SuppressedViolation is a violation exempted by a suppression.
+type SuppressedViolation struct {
+	Violation
+	Suppression Suppression
+}
+
+// coversChecker reports whether the suppression applies to the checker.
+func (s Suppression) coversChecker(id string) bool {
+	return len(s.CheckerIDs) == 0 || slices.Contains(s.CheckerIDs, id)
+}
+
+// TranscriptSuppressions finds IgnoreMarker directives in the messages
+// typed by the user. Text an agent writes, including subagent prompts,
+// can't suppress violations.
+func TranscriptSuppressions(t *transcript.Transcript) []Suppression {
+	suppressions, _ := transcriptMarkers(t)
+	return suppressions
+}
+
+// transcriptMarkers returns the suppressions of valid IgnoreMarker
+// directives typed by the user, and a warning for each directive that
+// names unknown checkers and so suppresses nothing.
+func transcriptMarkers(t *transcript.Transcript) ([]Suppression, []Violation) {
+	var suppressions []Suppression
+	var problems []Violation
+	for _, msg := range humanMessages(t) {
+		for line := range strings.Lines(msg.text) {
+			s, unknown, ok := parseIgnoreMarker(line)
+			if !ok {
+				continue
+			}
+			if len(unknown) > 0 {
+				problems = append(problems, Violation{
+					CheckerID: IgnoreMarker,
+					Rule:      "Suppression",
+					Severity:  SeverityWarning,
+					Message:   fmt.Sprintf("Ignore marker names unknown checker %s; nothing was suppressed (put reasons after --)", strings.Join(unknown, ", ")),
+					EventUUID: msg.uuid,
+					Context:   map[string]string{"marker": truncate(strings.TrimSpace(line), 100)},
+				})
+				continue
+			}
+			s.SessionID = t.SessionID
+			s.EventUUID = msg.uuid
+			suppressions = append(suppressions, s)
+		}
+	}
+	return suppressions, problems
+}
+
+// parseIgnoreMarker parses a line such as
+// "agents-lint:ignore git-branch -- greenfield project".
+// Words between the marker and "--" are checker IDs; any that aren't
+// registered are returned as unknown, so that a typo doesn't suppress
+// every checker.
+func parseIgnoreMarker(line string) (Suppression, []string, bool) {
+	_, rest, ok := strings.Cut(line, IgnoreMarker)
+	if !ok {
+		return Suppression{}, nil, false
+	}
+	s := Suppression{Source: "transcript"}
+
+	rest, s.Reason, _ = strings.Cut(rest, "--")
+	s.Reason = strings.TrimSpace(s.Reason)
+
+	var unknown []string
+	fields := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	for _, field := range fields {
+		if GetByID(field) == nil {
+			unknown = append(unknown, strconv.Quote(field))
+			continue
+		}
+		s.CheckerIDs = append(s.CheckerIDs, field)
+	}
+	return s, unknown, true
+}
+
+// suppressor matches violations against suppressions.
+type suppressor struct {
+	sessionID    string
+	suppressions []Suppression
+	position     map[string]int // event UUID or tool_use ID -> event index
+	end          int
+}
+
+func newSuppressor(t *transcript.Transcript, suppressions []Suppression) *suppressor {
+	return &suppressor{
+		sessionID:    t.SessionID,
+		suppressions: suppressions,
+		position:     eventPositions(t),
+		end:          len(t.Events),
+	}
+}
+
+// match returns the first suppression covering the violation.
+func (s *suppressor) match(v Violation) (Suppression, bool) {
+	for _, sup := range s.suppressions {
+		if sup.SessionID != "" && sup.SessionID != "*" && sup.SessionID != s.sessionID {
+			continue
+		}
+		if !sup.coversChecker(v.CheckerID) {
+			continue
+		}
+
+		if sup.ID != "" {
+			if sup.ID == v.ToolCallID || sup.ID == v.EventUUID {
+				return sup, true
+			}
+			continue
+		}
+
+		// Markers apply to violations at or after the message containing them
+		if s.locate(v) >= s.locate(Violation{EventUUID: sup.EventUUID}) {
+			return sup, true
+		}
+	}
+	return Suppression{}, false
+}
+
+// locate returns the event index of a violation, or the end of the
+// transcript for violations about the session as a whole.
+func (s *suppressor) locate(v Violation) int {
+	if i, ok := s.position[v.ToolCallID]; ok {
+		return i
+	}
+	if i, ok := s.position[v.EventUUID]; ok {
+		return i
+	}
+	return s.end
+}
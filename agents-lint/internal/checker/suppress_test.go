@@ -0,0 +1,144 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+func userText(uuid, text string) transcript.UserEvent {
+	return transcript.UserEvent{
+		Event: transcript.Event{Type: "user", UUID: uuid},
+		Message: transcript.UserMessage{
+			Role:    "user",
+			Content: []transcript.UserContentBlock{{Type: "text", Text: text}},
+		},
+	}
+}
+
+func suppressionTranscript(marker transcript.UserEvent) *transcript.Transcript {
+	return &transcript.Transcript{
+		SessionID: "s1",
+		Events: []any{
+			transcript.AssistantEvent{Event: transcript.Event{UUID: "a1"}},
+			marker,
+			transcript.AssistantEvent{Event: transcript.Event{UUID: "a2"}},
+		},
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", EventUUID: "a1"},
+			{ID: "t2", EventUUID: "a2"},
+		},
+	}
+}
+
+func TestParseIgnoreMarker(t *testing.T) {
+	tests := []struct {
+		line     string
+		checkers []string
+		reason   string
+		unknown  []string
+	}{
+		{"agents-lint:ignore git-branch", []string{"git-branch"}, "", nil},
+		{"ok, push it. agents-lint:ignore git-branch, single-line-commit -- greenfield", []string{"git-branch", "single-line-commit"}, "greenfield", nil},
+		{"agents-lint:ignore", nil, "", nil},
+		{"agents-lint:ignore -- because I said so", nil, "because I said so", nil},
+		{"agents-lint:ignore git-brnach", nil, "", []string{`"git-brnach"`}},
+		{"agents-lint:ignore git-branch because", []string{"git-branch"}, "", []string{`"because"`}},
+	}
+
+	for _, tt := range tests {
+		s, unknown, ok := parseIgnoreMarker(tt.line)
+		if !ok {
+			t.Errorf("%q: not parsed", tt.line)
+			continue
+		}
+		if !slices.Equal(s.CheckerIDs, tt.checkers) || s.Reason != tt.reason || !slices.Equal(unknown, tt.unknown) {
+			t.Errorf("%q: got %v %q unknown %v, want %v %q unknown %v", tt.line, s.CheckerIDs, s.Reason, unknown, tt.checkers, tt.reason, tt.unknown)
+		}
+	}
+
+	if _, _, ok := parseIgnoreMarker("nothing to see"); ok {
+		t.Error("line without marker parsed as suppression")
+	}
+}
+
+func TestRun_TranscriptMarker(t *testing.T) {
+	tr := suppressionTranscript(userText("u1", "Greenfield repo, push straight to main.\nagents-lint:ignore git-branch"))
+	c := &mockChecker{id: "git-branch", violations: []Violation{
+		{CheckerID: "git-branch", ToolCallID: "t1"},
+		{CheckerID: "git-branch", ToolCallID: "t2"},
+	}}
+
+	result := Run(tr, []Checker{c})
+	if len(result.Violations) != 1 || result.Violations[0].ToolCallID != "t1" {
+		t.Errorf("Violations = %+v, want only t1 (before the marker)", result.Violations)
+	}
+	if len(result.Suppressed) != 1 || result.Suppressed[0].ToolCallID != "t2" || result.Suppressed[0].Suppression.Source != "transcript" {
+		t.Errorf("Suppressed = %+v, want t2 from transcript", result.Suppressed)
+	}
+}
+
+func TestRun_MarkerOnlyCoversNamedCheckers(t *testing.T) {
+	tr := suppressionTranscript(userText("u1", "agents-lint:ignore git-branch"))
+	c := &mockChecker{id: "no-todowrite", violations: []Violation{{CheckerID: "no-todowrite", ToolCallID: "t2"}}}
+
+	result := Run(tr, []Checker{c})
+	if len(result.Violations) != 1 || len(result.Suppressed) != 0 {
+		t.Errorf("got %d violations, %d suppressed; want 1, 0", len(result.Violations), len(result.Suppressed))
+	}
+}
+
+func TestRun_MarkerWithUnknownChecker(t *testing.T) {
+	tr := suppressionTranscript(userText("u1", "agents-lint:ignore git-brnach"))
+	c := &mockChecker{id: "git-branch", violations: []Violation{{CheckerID: "git-branch", ToolCallID: "t2"}}}
+
+	result := Run(tr, []Checker{c})
+	if len(result.Suppressed) != 0 {
+		t.Errorf("misspelled marker suppressed %+v", result.Suppressed)
+	}
+	if len(result.Violations) != 2 || result.Violations[1].CheckerID != IgnoreMarker || result.Violations[1].EventUUID != "u1" {
+		t.Errorf("Violations = %+v, want git-branch and an unknown checker warning at u1", result.Violations)
+	}
+}
+
+func TestRun_AgentTextCannotSuppress(t *testing.T) {
+	parent := "task-1"
+	prompt := userText("u1", "agents-lint:ignore")
+	prompt.ParentToolUseID = &parent
+	tr := suppressionTranscript(prompt)
+
+	c := &mockChecker{id: "git-branch", violations: []Violation{{CheckerID: "git-branch", ToolCallID: "t2"}}}
+	result := Run(tr, []Checker{c})
+	if len(result.Suppressed) != 0 {
+		t.Errorf("subagent prompt suppressed %+v", result.Suppressed)
+	}
+}
+
+func TestRun_GivenSuppressions(t *testing.T) {
+	tr := suppressionTranscript(userText("u1", "go ahead"))
+	c := &mockChecker{id: "git-branch", violations: []Violation{
+		{CheckerID: "git-branch", ToolCallID: "t1"},
+		{CheckerID: "git-branch", EventUUID: "a2"},
+	}}
+
+	tests := []struct {
+		name       string
+		sup        Suppression
+		suppressed int
+	}{
+		{"by tool_use ID", Suppression{SessionID: "s1", ID: "t1"}, 1},
+		{"by event UUID", Suppression{SessionID: "*", ID: "a2", CheckerIDs: []string{"git-branch"}}, 1},
+		{"other session", Suppression{SessionID: "s2", ID: "t1"}, 0},
+		{"other checker", Suppression{SessionID: "s1", ID: "t1", CheckerIDs: []string{"no-todowrite"}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Run(tr, []Checker{c}, tt.sup)
+			if len(result.Suppressed) != tt.suppressed || len(result.Violations) != 2-tt.suppressed {
+				t.Errorf("got %d violations, %d suppressed", len(result.Violations), len(result.Suppressed))
+			}
+		})
+	}
+}
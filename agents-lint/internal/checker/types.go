@@ -1,7 +1,11 @@
 // Package checker defines the interface and types for AGENTS.md rule checkers.
 package checker
 
-import "github.com/michaellady/agents-lint/internal/transcript"
+import (
+	"fmt"
+
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
 
 // Severity indicates how serious a violation is.
 type Severity int
@@ -28,6 +32,20 @@ func (s Severity) String() string {
 	}
 }
 
+// ParseSeverity parses a severity name ("error", "warning", or "info").
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q (want error, warning, or info)", s)
+	}
+}
+
 // Violation represents a single rule violation found in a transcript.
 type Violation struct {
 	// CheckerID is the unique identifier of the checker that found this violation.
@@ -48,6 +66,16 @@ type Violation struct {
 	// ToolCallID is the tool_use ID if the violation is related to a tool call.
 	ToolCallID string
 
+	// Line is the 1-based transcript line of the event where the violation
+	// occurred, or 0 if unknown, and Offset is the byte offset of that line.
+	// Run fills them in from ToolCallID or EventUUID.
+	Line   int
+	Offset int64
+
+	// Agent names the agent scope responsible ("main" or a subagent such as
+	// "general-purpose (toolu_01)"). Empty if the checker is not agent-aware.
+	Agent string
+
 	// Context provides additional details for debugging.
 	Context map[string]string
 }
@@ -64,6 +92,35 @@ type Checker interface {
 	Check(t *transcript.Transcript) []Violation
 }
 
+// Configurable is implemented by checkers that accept options from the
+// project configuration file.
+type Configurable interface {
+	Checker
+
+	// Configure applies the checker's options. decode unmarshals the options
+	// into a struct using yaml field tags; it fails on unknown fields.
+	Configure(decode func(v any) error) error
+}
+
+// WithSeverity returns a checker that reports c's violations at severity s.
+func WithSeverity(c Checker, s Severity) Checker {
+	return severityOverride{Checker: c, severity: s}
+}
+
+// severityOverride rewrites the severity of another checker's violations.
+type severityOverride struct {
+	Checker
+	severity Severity
+}
+
+func (o severityOverride) Check(t *transcript.Transcript) []Violation {
+	violations := o.Checker.Check(t)
+	for i := range violations {
+		violations[i].Severity = o.severity
+	}
+	return violations
+}
+
 // Result contains the output of running all checkers on a transcript.
 type Result struct {
 	// TranscriptPath is the path to the transcript file that was checked.
@@ -72,8 +129,15 @@ type Result struct {
 	// Violations is all violations found across all checkers.
 	Violations []Violation
 
+	// Suppressed are violations exempted by a suppression. They are not
+	// counted by Summary.
+	Suppressed []SuppressedViolation
+
 	// CheckersRun lists the IDs of all checkers that were executed.
 	CheckersRun []string
+
+	// Fixed counts baseline violations that no longer occur.
+	Fixed int
 }
 
 // Summary returns counts of violations by severity.
@@ -1,9 +1,8 @@
 package checker
 
 import (
-	"encoding/json"
+	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/michaellady/agents-lint/internal/transcript"
 )
@@ -12,23 +11,24 @@ func init() {
 	Register(&UserApproval{})
 }
 
-// UserApproval checks that user approval is requested before working on issues.
+// UserApproval checks that the user approves before work starts on issues.
 // Per AGENTS.md Rule 4: "Request approval before working on any bead issue"
-type UserApproval struct{}
+type UserApproval struct {
+	// ApprovalPatterns match approval requests in assistant messages.
+	// Defaults to approvalPatterns if nil.
+	ApprovalPatterns []*regexp.Regexp
+}
 
 func (c *UserApproval) ID() string {
 	return "user-approval"
 }
 
 func (c *UserApproval) Description() string {
-	return "Ensures user approval is requested before working on bead issues (Rule 4)"
+	return "Ensures the user approves before work starts on bead issues (Rule 4)"
 }
 
 // Patterns for detecting approval-related content
 var (
-	// Pattern for bd update with in_progress status
-	bdInProgressPattern = regexp.MustCompile(`bd\s+update\s+\S+\s+--status\s+in_progress`)
-
 	// Patterns for approval requests in assistant messages
 	approvalPatterns = []*regexp.Regexp{
 		regexp.MustCompile(`(?i)proceed\s*\?`),
@@ -40,106 +40,93 @@ var (
 	}
 )
 
-func (c *UserApproval) Check(t *transcript.Transcript) []Violation {
-	var violations []Violation
-
-	// Track assistant messages and their indices
-	type msgInfo struct {
-		eventIdx int
-		uuid     string
-		text     string
+// Configure sets options from the project configuration file.
+// Patterns are regular expressions matched against assistant text.
+func (c *UserApproval) Configure(decode func(any) error) error {
+	var opts struct {
+		ApprovalPatterns []string `yaml:"approval_patterns"`
+	}
+	if err := decode(&opts); err != nil {
+		return err
+	}
+	if opts.ApprovalPatterns == nil {
+		return nil
 	}
-	var assistantMsgs []msgInfo
 
-	for i, event := range t.Events {
-		assitantEv, ok := event.(transcript.AssistantEvent)
-		if !ok {
-			continue
+	patterns := make([]*regexp.Regexp, len(opts.ApprovalPatterns))
+	for i, p := range opts.ApprovalPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("approval_patterns: %w", err)
 		}
+		patterns[i] = re
+	}
+	c.ApprovalPatterns = patterns
+	return nil
+}
 
-		// Collect text content from assistant
-		var text strings.Builder
-		for _, content := range assitantEv.Message.Content {
-			if content.Type == "text" {
-				text.WriteString(content.Text)
-			}
-		}
-		if text.Len() > 0 {
-			assistantMsgs = append(assistantMsgs, msgInfo{
-				eventIdx: i,
-				uuid:     assitantEv.UUID,
-				text:     text.String(),
-			})
-		}
+func (c *UserApproval) Check(t *transcript.Transcript) []Violation {
+	var violations []Violation
+
+	patterns := c.ApprovalPatterns
+	if patterns == nil {
+		patterns = approvalPatterns
 	}
 
+	human := humanMessages(t)
+	assistant := assistantMessages(t)
+	positions := eventPositions(t)
+
 	// Check each tool call for bd update --status in_progress
 	for _, tc := range t.ToolCalls {
 		if tc.Name != "Bash" {
 			continue
 		}
 
-		var input BashInput
-		if err := json.Unmarshal(tc.Input, &input); err != nil {
+		input, cmds, ok := bashCommands(tc)
+		if !ok || !anyBdInProgress(cmds) {
 			continue
 		}
 
-		if !bdInProgressPattern.MatchString(input.Command) {
+		// Found a bd update to in_progress - pair the most recent approval
+		// request before it with the user's reply
+		a, found := findApproval(assistant, human, patterns, positions[tc.ID])
+		problem, severity, ok := approvalProblem(a, found)
+		if !ok {
 			continue
 		}
 
-		// Found a bd update to in_progress - check if there was an approval request
-		// Look at the most recent assistant text before this tool call
-		approvalFound := false
-
-		// Find the event index for this tool call
-		toolEventIdx := -1
-		for i, event := range t.Events {
-			assitantEv, ok := event.(transcript.AssistantEvent)
-			if !ok {
-				continue
-			}
-			for _, content := range assitantEv.Message.Content {
-				if content.Type == "tool_use" && content.ID == tc.ID {
-					toolEventIdx = i
-					break
-				}
-			}
-			if toolEventIdx >= 0 {
-				break
-			}
+		context := map[string]string{
+			"command": truncate(input.Command, 100),
 		}
-
-		// Check preceding assistant messages for approval patterns
-		for _, msg := range assistantMsgs {
-			if msg.eventIdx >= toolEventIdx {
-				continue
-			}
-			for _, pattern := range approvalPatterns {
-				if pattern.MatchString(msg.text) {
-					approvalFound = true
-					break
-				}
-			}
-			if approvalFound {
-				break
-			}
-		}
-
-		if !approvalFound {
-			violations = append(violations, Violation{
-				CheckerID:  c.ID(),
-				Rule:       "Rule 4",
-				Severity:   SeverityWarning,
-				Message:    "Started work on bead issue without requesting user approval",
-				EventUUID:  tc.EventUUID,
-				ToolCallID: tc.ID,
-				Context: map[string]string{
-					"command": truncate(input.Command, 100),
-				},
-			})
+		if found {
+			context["request"] = truncate(a.request.text, 100)
 		}
+		violations = append(violations, Violation{
+			CheckerID:  c.ID(),
+			Rule:       "Rule 4",
+			Severity:   severity,
+			Message:    "Started work on bead issue " + problem,
+			EventUUID:  tc.EventUUID,
+			ToolCallID: tc.ID,
+			Context:    context,
+		})
 	}
 
 	return violations
 }
+
+// anyBdInProgress checks if any command moves a bead to in_progress
+// (bd update <id> --status in_progress).
+func anyBdInProgress(cmds []SimpleCommand) bool {
+	for _, cmd := range cmds {
+		sub, args, ok := cmd.Subcommand("bd")
+		if !ok || sub != "update" {
+			continue
+		}
+		if status, ok := flagValue(args, "-s", "--status"); ok && status == "in_progress" {
+			return true
+		}
+	}
+	return false
+}
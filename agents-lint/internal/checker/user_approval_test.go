@@ -41,6 +41,7 @@ func TestUserApproval_WithApproval(t *testing.T) {
 							},
 						},
 					},
+					userText("u1", "Yes"),
 					transcript.AssistantEvent{
 						Event: transcript.Event{UUID: "e2"},
 						Message: transcript.AssistantMessage{
@@ -179,3 +180,82 @@ func TestUserApproval_Registered(t *testing.T) {
 		t.Error("user-approval checker not registered")
 	}
 }
+
+func TestUserApproval_InProgressVariants(t *testing.T) {
+	commands := []string{
+		"bd update ISSUE-123 --status=in_progress",
+		"cd repo && bd update ISSUE-123 -s in_progress --json",
+		"bd --db .beads/beads.db update ISSUE-123 --status in_progress",
+	}
+
+	c := &UserApproval{}
+
+	for _, cmd := range commands {
+		bdInput, _ := json.Marshal(BashInput{Command: cmd})
+		tr := &transcript.Transcript{
+			Events: []any{
+				transcript.AssistantEvent{
+					Event: transcript.Event{UUID: "e1"},
+					Message: transcript.AssistantMessage{
+						Content: []transcript.ContentBlock{
+							{Type: "tool_use", ID: "t1", Name: "Bash", Input: bdInput},
+						},
+					},
+				},
+			},
+			ToolCalls: []transcript.ToolCall{
+				{ID: "t1", Name: "Bash", Input: bdInput, EventUUID: "e1"},
+			},
+		}
+
+		violations := c.Check(tr)
+		if len(violations) != 1 {
+			t.Errorf("expected 1 violation for %q, got %d", cmd, len(violations))
+		}
+	}
+}
+
+func TestUserApproval_Reply(t *testing.T) {
+	request := say("Ready to work on ISSUE-123. Proceed? [Yes/No]")
+	claim := bash("bd update ISSUE-123 --status in_progress")
+
+	tests := []struct {
+		name     string
+		turns    []any
+		severity Severity
+		message  string
+	}{
+		{"declined", []any{request, userText("", "No, wait for the design review"), claim}, SeverityError, "Started work on bead issue after the user declined"},
+		{"no reply", []any{request, claim}, SeverityWarning, "Started work on bead issue before the user replied to the approval request"},
+		{"unclear reply", []any{request, userText("", "What does it involve?"), claim}, SeverityWarning, "Started work on bead issue without an affirmative reply to the approval request"},
+		{"reply after claim", []any{request, claim, userText("", "yes")}, SeverityWarning, "Started work on bead issue before the user replied to the approval request"},
+	}
+
+	c := &UserApproval{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := c.Check(conversation(tt.turns...))
+			if len(violations) != 1 {
+				t.Fatalf("got %d violations, want 1", len(violations))
+			}
+			v := violations[0]
+			if v.Severity != tt.severity || v.Message != tt.message {
+				t.Errorf("got %v %q, want %v %q", v.Severity, v.Message, tt.severity, tt.message)
+			}
+		})
+	}
+}
+
+func TestUserApproval_FirstReplyCounts(t *testing.T) {
+	tr := conversation(
+		say("Ready to work on ISSUE-123. Proceed?"),
+		userText("", "no"),
+		userText("", "yes, actually go ahead"),
+		bash("bd update ISSUE-123 --status in_progress"),
+	)
+
+	violations := (&UserApproval{}).Check(tr)
+	if len(violations) != 1 || violations[0].Severity != SeverityError {
+		t.Errorf("violations = %+v, want one error for the refusal", violations)
+	}
+}
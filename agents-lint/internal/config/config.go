@@ -0,0 +1,165 @@
+// Package config loads .agents-lint.yaml project configuration.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+// FileName is the configuration file discovered by Find.
+const FileName = ".agents-lint.yaml"
+
+// Config is a project configuration file.
+type Config struct {
+	// Path is the file the configuration was loaded from.
+	Path string `yaml:"-"`
+
+	// Checkers configures individual checkers, keyed by checker ID.
+	Checkers map[string]CheckerConfig `yaml:"checkers"`
+}
+
+// CheckerConfig configures a single checker.
+type CheckerConfig struct {
+	// Enabled turns the checker on or off (default on).
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// Severity overrides the severity of the checker's violations.
+	Severity string `yaml:"severity,omitempty"`
+
+	// Options are passed to checkers implementing checker.Configurable.
+	Options yaml.Node `yaml:"options,omitempty"`
+}
+
+// Find looks for FileName in dir and its parents. It returns "" if none is found.
+func Find(dir string) (string, error) {
+	return findUp(dir, FileName)
+}
+
+// findUp looks for a file named name in dir and its parents.
+func findUp(dir, name string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates a configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+// Parse parses and validates configuration YAML.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config YAML: %w", err)
+	}
+
+	for id, cc := range cfg.Checkers {
+		c := checker.GetByID(id)
+		if c == nil {
+			return nil, fmt.Errorf("unknown checker %q", id)
+		}
+		if cc.Severity != "" {
+			if _, err := checker.ParseSeverity(cc.Severity); err != nil {
+				return nil, fmt.Errorf("checker %s: %w", id, err)
+			}
+		}
+		if _, ok := c.(checker.Configurable); !ok && !cc.Options.IsZero() {
+			return nil, fmt.Errorf("checker %s has no options", id)
+		}
+	}
+	return &cfg, nil
+}
+
+// Enabled reports whether the checker with the given ID should run by
+// default. A nil Config enables every checker.
+func (c *Config) Enabled(id string) bool {
+	if c == nil {
+		return true
+	}
+	cc, ok := c.Checkers[id]
+	return !ok || cc.Enabled == nil || *cc.Enabled
+}
+
+// Apply configures the given checkers with their options and wraps those
+// with a severity override. A nil Config returns the checkers unchanged.
+func (c *Config) Apply(checkers []checker.Checker) ([]checker.Checker, error) {
+	if c == nil {
+		return checkers, nil
+	}
+
+	applied := make([]checker.Checker, len(checkers))
+	for i, ch := range checkers {
+		cc, ok := c.Checkers[ch.ID()]
+		if !ok {
+			applied[i] = ch
+			continue
+		}
+
+		if configurable, ok := ch.(checker.Configurable); ok && !cc.Options.IsZero() {
+			if err := configurable.Configure(decodeStrict(&cc.Options)); err != nil {
+				return nil, fmt.Errorf("checker %s options: %w", ch.ID(), err)
+			}
+		}
+
+		if cc.Severity != "" {
+			severity, err := checker.ParseSeverity(cc.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("checker %s: %w", ch.ID(), err)
+			}
+			ch = checker.WithSeverity(ch, severity)
+		}
+		applied[i] = ch
+	}
+	return applied, nil
+}
+
+// decodeStrict returns a decode function for a checker's options that
+// rejects fields the checker does not define.
+func decodeStrict(node *yaml.Node) func(any) error {
+	return func(v any) error {
+		data, err := yaml.Marshal(node)
+		if err != nil {
+			return err
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		return dec.Decode(v)
+	}
+}
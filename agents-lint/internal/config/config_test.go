@@ -0,0 +1,160 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/transcript"
+)
+
+const sampleConfig = `
+checkers:
+  static-types:
+    enabled: false
+  commit-after-edit:
+    severity: error
+    options:
+      max_tool_calls_before_commit: 2
+  parallel-worktree:
+    options:
+      exempt_agent_types: [Explore, reviewer]
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if cfg.Enabled("static-types") {
+		t.Error("static-types should be disabled")
+	}
+	if !cfg.Enabled("commit-after-edit") || !cfg.Enabled("git-branch") {
+		t.Error("configured and unconfigured checkers should be enabled")
+	}
+
+	var nilCfg *Config
+	if !nilCfg.Enabled("static-types") {
+		t.Error("nil config should enable every checker")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unknown checker", "checkers:\n  no-such-checker: {}\n", "unknown checker"},
+		{"bad severity", "checkers:\n  git-branch:\n    severity: fatal\n", "unknown severity"},
+		{"unknown key", "checker:\n  git-branch: {}\n", "field checker not found"},
+		{"options without Configurable", "checkers:\n  no-todowrite:\n    options:\n      x: 1\n", "has no options"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	cfg, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !cfg.Enabled("git-branch") {
+		t.Error("empty config should enable every checker")
+	}
+}
+
+func TestApply(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Use fresh instances so registered checkers are left untouched
+	commit := &checker.CommitAfterEdit{}
+	worktree := &checker.ParallelWorktree{}
+	checkers, err := cfg.Apply([]checker.Checker{commit, worktree})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if commit.MaxToolCallsBeforeCommit != 2 {
+		t.Errorf("MaxToolCallsBeforeCommit = %d, want 2", commit.MaxToolCallsBeforeCommit)
+	}
+	if len(worktree.ExemptAgentTypes) != 2 || worktree.ExemptAgentTypes[1] != "reviewer" {
+		t.Errorf("ExemptAgentTypes = %v", worktree.ExemptAgentTypes)
+	}
+	if checkers[0].ID() != "commit-after-edit" || checkers[1] != worktree {
+		t.Errorf("Apply returned %v", checkers)
+	}
+
+	// The edit is never committed within 2 calls; severity is overridden to error
+	editInput, _ := json.Marshal(map[string]string{"file_path": "/test/a.go"})
+	tr := &transcript.Transcript{
+		ToolCalls: []transcript.ToolCall{
+			{ID: "t1", Name: "Edit", Input: editInput},
+			{ID: "t2", Name: "Read"},
+			{ID: "t3", Name: "Read"},
+			{ID: "t4", Name: "Read"},
+		},
+	}
+	violations := checkers[0].Check(tr)
+	if len(violations) == 0 || violations[0].Severity != checker.SeverityError {
+		t.Errorf("violations = %+v, want error severity", violations)
+	}
+}
+
+func TestApply_BadOptions(t *testing.T) {
+	tests := []string{
+		"checkers:\n  commit-after-edit:\n    options:\n      max_calls: 3\n",
+		"checkers:\n  commit-after-edit:\n    options:\n      max_tool_calls_before_commit: -1\n",
+		"checkers:\n  user-approval:\n    options:\n      approval_patterns: ['(']\n",
+	}
+
+	for _, data := range tests {
+		cfg, err := Parse([]byte(data))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		checkers := []checker.Checker{&checker.CommitAfterEdit{}, &checker.UserApproval{}}
+		if _, err := cfg.Apply(checkers); err == nil {
+			t.Errorf("Apply(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := Find(nested); err != nil || got != "" {
+		t.Errorf("Find without config = (%q, %v), want none", got, err)
+	}
+
+	want := filepath.Join(root, FileName)
+	if err := os.WriteFile(want, []byte(sampleConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Find(nested); err != nil || got != want {
+		t.Errorf("Find = (%q, %v), want %q", got, err, want)
+	}
+
+	cfg, err := Load(want)
+	if err != nil || cfg.Path != want {
+		t.Errorf("Load = (%+v, %v)", cfg, err)
+	}
+}
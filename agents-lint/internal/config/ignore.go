@@ -0,0 +1,73 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+// IgnoreFileName is the suppression file discovered by FindIgnore.
+const IgnoreFileName = ".agents-lint-ignore"
+
+// FindIgnore looks for IgnoreFileName in dir and its parents. It returns
+// "" if none is found.
+func FindIgnore(dir string) (string, error) {
+	return findUp(dir, IgnoreFileName)
+}
+
+// LoadIgnore reads a suppression file.
+func LoadIgnore(path string) ([]checker.Suppression, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ignore file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseIgnore(f, path)
+}
+
+// ParseIgnore parses suppressions, one per line:
+//
+//	<session_id> <tool_use_id|event_uuid> [checker,...] [# reason]
+//
+// A session_id of "*" matches any session, and omitting the checkers
+// suppresses all of them. Blank lines and lines starting with # are skipped.
+func ParseIgnore(r io.Reader, source string) ([]checker.Suppression, error) {
+	var suppressions []checker.Suppression
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line, reason, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: want <session_id> <tool_use_id|event_uuid> [checker,...]", source, n)
+		}
+
+		s := checker.Suppression{
+			SessionID: fields[0],
+			ID:        fields[1],
+			Reason:    strings.TrimSpace(reason),
+			Source:    fmt.Sprintf("%s:%d", source, n),
+		}
+		if len(fields) == 3 {
+			for _, id := range strings.Split(fields[2], ",") {
+				if checker.GetByID(id) == nil {
+					return nil, fmt.Errorf("%s:%d: unknown checker %q", source, n, id)
+				}
+				s.CheckerIDs = append(s.CheckerIDs, id)
+			}
+		}
+		suppressions = append(suppressions, s)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read ignore file: %w", err)
+	}
+	return suppressions, nil
+}
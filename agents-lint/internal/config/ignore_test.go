@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIgnore(t *testing.T) {
+	data := `# Sanctioned exceptions
+s1 toolu_01 git-branch  # greenfield push approved in #123
+
+* evt-9 git-branch,single-line-commit
+s2 toolu_02
+`
+	suppressions, err := ParseIgnore(strings.NewReader(data), ".agents-lint-ignore")
+	if err != nil {
+		t.Fatalf("ParseIgnore failed: %v", err)
+	}
+	if len(suppressions) != 3 {
+		t.Fatalf("got %d suppressions, want 3", len(suppressions))
+	}
+
+	first := suppressions[0]
+	if first.SessionID != "s1" || first.ID != "toolu_01" || len(first.CheckerIDs) != 1 ||
+		first.Reason != "greenfield push approved in #123" || first.Source != ".agents-lint-ignore:2" {
+		t.Errorf("first = %+v", first)
+	}
+	if len(suppressions[1].CheckerIDs) != 2 {
+		t.Errorf("second CheckerIDs = %v, want 2", suppressions[1].CheckerIDs)
+	}
+	if suppressions[2].CheckerIDs != nil {
+		t.Errorf("third CheckerIDs = %v, want all", suppressions[2].CheckerIDs)
+	}
+}
+
+func TestParseIgnore_Errors(t *testing.T) {
+	tests := []string{
+		"s1\n",
+		"s1 toolu_01 git-branch extra\n",
+		"s1 toolu_01 no-such-checker\n",
+	}
+
+	for _, data := range tests {
+		if _, err := ParseIgnore(strings.NewReader(data), "f"); err == nil {
+			t.Errorf("ParseIgnore(%q) succeeded, want error", data)
+		}
+	}
+}
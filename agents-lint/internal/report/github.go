@@ -0,0 +1,83 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+// WriteGitHub outputs violations as GitHub Actions workflow commands, which
+// annotate the transcript inline on pull requests.
+// See https://docs.github.com/actions/reference/workflow-commands-for-github-actions.
+func WriteGitHub(w io.Writer, result *checker.Result) {
+	for _, v := range result.Violations {
+		props := []string{"file=" + escapeProperty(result.TranscriptPath)}
+		if v.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", v.Line))
+		}
+		props = append(props, "title="+escapeProperty(v.CheckerID))
+
+		fmt.Fprintf(w, "::%s %s::%s\n", githubCommand(v.Severity), strings.Join(props, ","),
+			escapeData(v.Rule+": "+v.Message))
+	}
+}
+
+// githubCommand maps a severity to an annotation command.
+func githubCommand(s checker.Severity) string {
+	switch s {
+	case checker.SeverityError:
+		return "error"
+	case checker.SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return dataEscaper.Replace(s)
+}
+
+// escapeProperty escapes a workflow command property value.
+func escapeProperty(s string) string {
+	return propertyEscaper.Replace(s)
+}
+
+// WriteGitHubSummary outputs a Markdown job summary, for appending to the
+// file named by $GITHUB_STEP_SUMMARY.
+func WriteGitHubSummary(w io.Writer, result *checker.Result) {
+	fmt.Fprintf(w, "### agents-lint: `%s`\n\n", result.TranscriptPath)
+	writeCounts(w, summarize(result))
+	fmt.Fprintln(w)
+
+	if len(result.Violations) == 0 {
+		fmt.Fprint(w, "No violations found.\n\n")
+		return
+	}
+
+	fmt.Fprintln(w, "| Severity | Checker | Rule | Line | Message |")
+	fmt.Fprintln(w, "|----------|---------|------|------|---------|")
+	for _, v := range result.Violations {
+		line := ""
+		if v.Line > 0 {
+			line = fmt.Sprint(v.Line)
+		}
+		fmt.Fprintf(w, "| %s | `%s` | %s | %s | %s |\n",
+			v.Severity, v.CheckerID, escapeCell(v.Rule), line, escapeCell(v.Message))
+	}
+	fmt.Fprintln(w)
+}
+
+// escapeCell makes text safe for a Markdown table cell.
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+func TestWriteGitHub(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "testdata/a,b.ndjson",
+		Violations: []checker.Violation{
+			{CheckerID: "git-branch", Rule: "Rule 3", Severity: checker.SeverityError, Message: "Direct push", Line: 7},
+			{CheckerID: "commit-after-edit", Rule: "Rule 6", Severity: checker.SeverityWarning, Message: "50% done\nno commit"},
+			{CheckerID: "context-report", Rule: "Rule 5", Severity: checker.SeverityInfo, Message: "missing"},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteGitHub(&buf, result)
+
+	want := []string{
+		"::error file=testdata/a%2Cb.ndjson,line=7,title=git-branch::Rule 3: Direct push",
+		"::warning file=testdata/a%2Cb.ndjson,title=commit-after-edit::Rule 6: 50%25 done%0Ano commit",
+		"::notice file=testdata/a%2Cb.ndjson,title=context-report::Rule 5: missing",
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(got), len(want), buf.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWriteGitHubSummary(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "session.ndjson",
+		Violations: []checker.Violation{
+			{CheckerID: "git-branch", Rule: "Rule 3", Severity: checker.SeverityError, Message: "a | b", Line: 7},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteGitHubSummary(&buf, result)
+	out := buf.String()
+
+	for _, want := range []string{
+		"### agents-lint: `session.ndjson`",
+		"1 errors, 0 warnings, 0 info",
+		"| error | `git-branch` | Rule 3 | 7 | a \\| b |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	WriteGitHubSummary(&buf, &checker.Result{TranscriptPath: "clean.ndjson"})
+	if !strings.Contains(buf.String(), "No violations found.") {
+		t.Errorf("clean summary = %q", buf.String())
+	}
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/scenario"
+)
+
+// JUnit XML types, in the dialect read by Jenkins, GitLab, and most CI
+// dashboards.
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// add appends a test case and updates the suite's counts.
+func (s *junitTestSuite) add(tc junitTestCase) {
+	s.Tests++
+	s.Time += tc.Time
+	switch {
+	case tc.Failure != nil:
+		s.Failures++
+	case tc.Error != nil:
+		s.Errors++
+	case tc.Skipped != nil:
+		s.Skipped++
+	}
+	s.Cases = append(s.Cases, tc)
+}
+
+// writeJUnit totals the suites and writes them as an XML document.
+func writeJUnit(w io.Writer, name string, suites []junitTestSuite) error {
+	doc := junitTestSuites{Name: name, Suites: suites}
+	for _, s := range suites {
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Errors += s.Errors
+		doc.Skipped += s.Skipped
+		doc.Time += s.Time
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJUnit outputs check results as JUnit XML, with a test suite per
+// transcript and a test case per checker. A checker's violations are
+// reported as a single failure of the highest severity found.
+func WriteJUnit(w io.Writer, results ...*checker.Result) error {
+	suites := make([]junitTestSuite, 0, len(results))
+	for _, result := range results {
+		byChecker := make(map[string][]checker.Violation)
+		for _, v := range result.Violations {
+			byChecker[v.CheckerID] = append(byChecker[v.CheckerID], v)
+		}
+
+		suite := junitTestSuite{Name: result.TranscriptPath}
+		for _, id := range result.CheckersRun {
+			tc := junitTestCase{Name: id, ClassName: result.TranscriptPath}
+			if violations := byChecker[id]; len(violations) > 0 {
+				tc.Failure = junitFailure(result.TranscriptPath, violations)
+			}
+			suite.add(tc)
+		}
+		suites = append(suites, suite)
+	}
+	return writeJUnit(w, "agents-lint", suites)
+}
+
+// junitFailure summarizes a checker's violations.
+func junitFailure(path string, violations []checker.Violation) *junitProblem {
+	worst := checker.SeverityInfo
+	var text strings.Builder
+	for _, v := range violations {
+		worst = max(worst, v.Severity)
+		fmt.Fprintf(&text, "%s[%s] %s: %s\n", location(path, v), strings.ToUpper(v.Severity.String()), v.Rule, v.Message)
+	}
+
+	message := violations[0].Message
+	if len(violations) > 1 {
+		message = fmt.Sprintf("%d violations", len(violations))
+	}
+	return &junitProblem{Message: message, Type: worst.String(), Text: text.String()}
+}
+
+// WriteScenarioJUnit outputs agents-test results as JUnit XML, with a
+// test case per scenario. The saved transcript is attached as system-out.
+func WriteScenarioJUnit(w io.Writer, results []*scenario.Result) error {
+	suite := junitTestSuite{Name: "agents-test"}
+	for _, r := range results {
+		tc := junitTestCase{
+			Name:      r.Scenario.Name,
+			ClassName: "agents-test",
+			Time:      float64(r.DurationMS) / 1000,
+		}
+		if r.TranscriptPath != "" {
+			tc.SystemOut = fmt.Sprintf("[[ATTACHMENT|%s]]", r.TranscriptPath)
+		}
+
+		switch {
+		case r.Error != nil:
+			tc.Error = &junitProblem{Message: r.Error.Error()}
+		case r.TranscriptPath == "":
+			tc.Skipped = &junitSkipped{Message: "not run"}
+		case !r.Passed:
+			tc.Failure = scenarioFailure(r)
+		}
+		suite.add(tc)
+	}
+	return writeJUnit(w, "agents-test", []junitTestSuite{suite})
+}
+
+// scenarioFailure describes expected versus found violations.
+func scenarioFailure(r *scenario.Result) *junitProblem {
+	expected := "none"
+	if len(r.Scenario.ExpectViolations) > 0 {
+		expected = strings.Join(r.Scenario.ExpectViolations, ", ")
+	}
+	found := "none"
+	if len(r.FoundViolations) > 0 {
+		found = strings.Join(r.FoundViolations, ", ")
+	}
+
+	return &junitProblem{
+		Message: fmt.Sprintf("expected violations: %s; found: %s", expected, found),
+		Type:    "expectation",
+		Text:    fmt.Sprintf("Expected: %s\nFound: %s\nViolations: %d\n", expected, found, r.ViolationCount),
+	}
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+	"github.com/michaellady/agents-lint/internal/scenario"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "session.ndjson",
+		CheckersRun:    []string{"git-branch", "no-todowrite", "context-report"},
+		Violations: []checker.Violation{
+			{CheckerID: "git-branch", Rule: "Rule 3", Severity: checker.SeverityWarning, Message: "first", Line: 4},
+			{CheckerID: "git-branch", Rule: "Rule 3", Severity: checker.SeverityError, Message: "second", Line: 9},
+			{CheckerID: "context-report", Rule: "Rule 5", Severity: checker.SeverityInfo, Message: "no report"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, result); err != nil {
+		t.Fatalf("WriteJUnit() error: %v", err)
+	}
+
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Tests != 3 || doc.Failures != 2 || len(doc.Suites) != 1 {
+		t.Fatalf("tests = %d, failures = %d, suites = %d; want 3, 2, 1", doc.Tests, doc.Failures, len(doc.Suites))
+	}
+
+	cases := doc.Suites[0].Cases
+	branch := cases[0].Failure
+	if branch == nil || branch.Type != "error" || branch.Message != "2 violations" {
+		t.Fatalf("git-branch failure = %+v, want 2 violations of type error", branch)
+	}
+	if !strings.Contains(branch.Text, "session.ndjson:9: [ERROR] Rule 3: second") {
+		t.Errorf("failure text = %q, want located violations", branch.Text)
+	}
+	if cases[1].Failure != nil {
+		t.Errorf("no-todowrite failure = %+v, want none", cases[1].Failure)
+	}
+	if f := cases[2].Failure; f == nil || f.Message != "no report" || f.Type != "info" {
+		t.Errorf("context-report failure = %+v", f)
+	}
+}
+
+func TestWriteScenarioJUnit(t *testing.T) {
+	results := []*scenario.Result{
+		{
+			Scenario:       &scenario.Scenario{Name: "pass"},
+			TranscriptPath: "out/pass.ndjson",
+			Passed:         true,
+			DurationMS:     1500,
+		},
+		{
+			Scenario:        &scenario.Scenario{Name: "fail", ExpectViolations: []string{"git-branch"}},
+			TranscriptPath:  "out/fail.ndjson",
+			FoundViolations: []string{"no-todowrite"},
+			ViolationCount:  1,
+		},
+		{Scenario: &scenario.Scenario{Name: "broken"}, Error: errors.New("timeout after 120s")},
+		{Scenario: &scenario.Scenario{Name: "dry"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteScenarioJUnit(&buf, results); err != nil {
+		t.Fatalf("WriteScenarioJUnit() error: %v", err)
+	}
+
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Errors != 1 || doc.Skipped != 1 {
+		t.Fatalf("counts = %+v", doc)
+	}
+
+	cases := doc.Suites[0].Cases
+	if cases[0].Time != 1.5 || cases[0].SystemOut != "[[ATTACHMENT|out/pass.ndjson]]" {
+		t.Errorf("pass = %+v, want 1.5s with transcript attachment", cases[0])
+	}
+	if f := cases[1].Failure; f == nil || f.Message != "expected violations: git-branch; found: no-todowrite" {
+		t.Errorf("fail failure = %+v", f)
+	}
+	if e := cases[2].Error; e == nil || e.Message != "timeout after 120s" {
+		t.Errorf("broken error = %+v", e)
+	}
+}
@@ -15,6 +15,7 @@ type JSONReport struct {
 	File        string          `json:"file"`
 	CheckersRun []string        `json:"checkers_run"`
 	Violations  []JSONViolation `json:"violations"`
+	Suppressed  []JSONViolation `json:"suppressed,omitempty"`
 	Summary     Summary         `json:"summary"`
 }
 
@@ -26,60 +27,126 @@ type JSONViolation struct {
 	Message    string            `json:"message"`
 	EventUUID  string            `json:"event_uuid,omitempty"`
 	ToolCallID string            `json:"tool_call_id,omitempty"`
+	Line       int               `json:"line,omitempty"`
+	Agent      string            `json:"agent,omitempty"`
 	Context    map[string]string `json:"context,omitempty"`
+
+	// SuppressedBy is set for suppressed violations.
+	SuppressedBy *JSONSuppression `json:"suppressed_by,omitempty"`
+}
+
+// JSONSuppression describes the suppression that exempted a violation.
+type JSONSuppression struct {
+	Source string `json:"source"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // Summary contains violation counts.
 type Summary struct {
-	Errors   int `json:"errors"`
-	Warnings int `json:"warnings"`
-	Infos    int `json:"infos"`
+	Errors     int `json:"errors"`
+	Warnings   int `json:"warnings"`
+	Infos      int `json:"infos"`
+	Suppressed int `json:"suppressed"`
+	Fixed      int `json:"fixed,omitempty"`
+}
+
+// JSONAggregateReport is the structured output format for several
+// transcripts.
+type JSONAggregateReport struct {
+	Files   []JSONReport `json:"files"`
+	Summary Summary      `json:"summary"`
+}
+
+// add adds the counts of another summary.
+func (s *Summary) add(o Summary) {
+	s.Errors += o.Errors
+	s.Warnings += o.Warnings
+	s.Infos += o.Infos
+	s.Suppressed += o.Suppressed
+	s.Fixed += o.Fixed
+}
+
+// summarize counts a result's violations.
+func summarize(result *checker.Result) Summary {
+	errors, warnings, infos := result.Summary()
+	return Summary{
+		Errors:     errors,
+		Warnings:   warnings,
+		Infos:      infos,
+		Suppressed: len(result.Suppressed),
+		Fixed:      result.Fixed,
+	}
 }
 
 // WriteJSON outputs the result as JSON.
 func WriteJSON(w io.Writer, result *checker.Result) error {
-	errors, warnings, infos := result.Summary()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonReport(result))
+}
+
+// WriteJSONAggregate outputs several results as one JSON document, with
+// the report for each file and combined totals.
+func WriteJSONAggregate(w io.Writer, results []*checker.Result) error {
+	aggregate := JSONAggregateReport{Files: make([]JSONReport, len(results))}
+	for i, result := range results {
+		aggregate.Files[i] = jsonReport(result)
+		aggregate.Summary.add(aggregate.Files[i].Summary)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(aggregate)
+}
 
+// jsonReport converts a result to its JSON form.
+func jsonReport(result *checker.Result) JSONReport {
 	report := JSONReport{
 		File:        result.TranscriptPath,
 		CheckersRun: result.CheckersRun,
 		Violations:  make([]JSONViolation, len(result.Violations)),
-		Summary: Summary{
-			Errors:   errors,
-			Warnings: warnings,
-			Infos:    infos,
-		},
+		Summary:     summarize(result),
 	}
 
 	for i, v := range result.Violations {
-		report.Violations[i] = JSONViolation{
-			CheckerID:  v.CheckerID,
-			Rule:       v.Rule,
-			Severity:   v.Severity.String(),
-			Message:    v.Message,
-			EventUUID:  v.EventUUID,
-			ToolCallID: v.ToolCallID,
-			Context:    v.Context,
-		}
+		report.Violations[i] = jsonViolation(v)
+	}
+	for _, s := range result.Suppressed {
+		jv := jsonViolation(s.Violation)
+		jv.SuppressedBy = &JSONSuppression{Source: s.Suppression.Source, Reason: s.Suppression.Reason}
+		report.Suppressed = append(report.Suppressed, jv)
 	}
+	return report
+}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(report)
+// jsonViolation converts a violation to its JSON form.
+func jsonViolation(v checker.Violation) JSONViolation {
+	return JSONViolation{
+		CheckerID:  v.CheckerID,
+		Rule:       v.Rule,
+		Severity:   v.Severity.String(),
+		Message:    v.Message,
+		EventUUID:  v.EventUUID,
+		ToolCallID: v.ToolCallID,
+		Line:       v.Line,
+		Agent:      v.Agent,
+		Context:    v.Context,
+	}
 }
 
 // WriteText outputs the result as human-readable text.
 func WriteText(w io.Writer, result *checker.Result, verbose bool) {
-	errors, warnings, infos := result.Summary()
-
 	// Print violations
 	if verbose || len(result.Violations) > 0 {
 		for _, v := range result.Violations {
 			severity := strings.ToUpper(v.Severity.String())
-			fmt.Fprintf(w, "[%s] %s: %s\n", severity, v.Rule, v.Message)
+			fmt.Fprintf(w, "%s[%s] %s: %s\n", location(result.TranscriptPath, v), severity, v.Rule, v.Message)
 			if v.ToolCallID != "" && verbose {
 				fmt.Fprintf(w, "  Tool call: %s\n", v.ToolCallID)
 			}
+			if v.Agent != "" && verbose {
+				fmt.Fprintf(w, "  Agent: %s\n", v.Agent)
+			}
 			if len(v.Context) > 0 && verbose {
 				for k, val := range v.Context {
 					fmt.Fprintf(w, "  %s: %s\n", k, val)
@@ -89,8 +156,47 @@ func WriteText(w io.Writer, result *checker.Result, verbose bool) {
 	}
 
 	if verbose {
+		for _, s := range result.Suppressed {
+			fmt.Fprintf(w, "%s[SUPPRESSED] %s: %s\n", location(result.TranscriptPath, s.Violation), s.Rule, s.Message)
+			fmt.Fprintf(w, "  Suppressed by: %s\n", s.Suppression.Source)
+			if s.Suppression.Reason != "" {
+				fmt.Fprintf(w, "  Reason: %s\n", s.Suppression.Reason)
+			}
+		}
 		fmt.Fprintf(w, "\nCheckers run: %s\n", strings.Join(result.CheckersRun, ", "))
 	}
 
-	fmt.Fprintf(w, "\n%s: %d errors, %d warnings, %d info\n", result.TranscriptPath, errors, warnings, infos)
+	fmt.Fprintf(w, "\n%s: ", result.TranscriptPath)
+	writeCounts(w, summarize(result))
+}
+
+// WriteTextTotals outputs the combined counts of several results.
+func WriteTextTotals(w io.Writer, results []*checker.Result) {
+	var total Summary
+	for _, result := range results {
+		total.add(summarize(result))
+	}
+	fmt.Fprintf(w, "\nTotal (%d files): ", len(results))
+	writeCounts(w, total)
+}
+
+// writeCounts outputs a summary line.
+func writeCounts(w io.Writer, s Summary) {
+	fmt.Fprintf(w, "%d errors, %d warnings, %d info", s.Errors, s.Warnings, s.Infos)
+	if s.Suppressed > 0 {
+		fmt.Fprintf(w, ", %d suppressed", s.Suppressed)
+	}
+	if s.Fixed > 0 {
+		fmt.Fprintf(w, ", %d fixed since baseline", s.Fixed)
+	}
+	fmt.Fprintln(w)
+}
+
+// location returns a "file:line: " prefix for a violation, in the form
+// editors recognize, or "" if its line is unknown.
+func location(path string, v checker.Violation) string {
+	if path == "" || v.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d: ", path, v.Line)
 }
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+func TestWriteText_Location(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "session.ndjson",
+		Violations: []checker.Violation{
+			{Rule: "Rule 2", Severity: checker.SeverityError, Message: "at line", Line: 12},
+			{Rule: "Rule 5", Severity: checker.SeverityWarning, Message: "session-wide"},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteText(&buf, result, false)
+	lines := strings.Split(buf.String(), "\n")
+
+	if want := "session.ndjson:12: [ERROR] Rule 2: at line"; lines[0] != want {
+		t.Errorf("line 0 = %q, want %q", lines[0], want)
+	}
+	if want := "[WARNING] Rule 5: session-wide"; lines[1] != want {
+		t.Errorf("line 1 = %q, want %q", lines[1], want)
+	}
+}
+
+func TestWriteJSON_Line(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "session.ndjson",
+		Violations: []checker.Violation{
+			{CheckerID: "no-todowrite", Severity: checker.SeverityError, Line: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, result); err != nil {
+		t.Fatalf("WriteJSON() error: %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if report.Violations[0].Line != 3 {
+		t.Errorf("line = %d, want 3", report.Violations[0].Line)
+	}
+}
+
+func TestWriteJSONAggregate(t *testing.T) {
+	results := []*checker.Result{
+		{TranscriptPath: "a.ndjson", Violations: []checker.Violation{{Severity: checker.SeverityError}}},
+		{TranscriptPath: "b.ndjson", Violations: []checker.Violation{{Severity: checker.SeverityWarning}}, Fixed: 2},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONAggregate(&buf, results); err != nil {
+		t.Fatalf("WriteJSONAggregate() error: %v", err)
+	}
+
+	var aggregate JSONAggregateReport
+	if err := json.Unmarshal(buf.Bytes(), &aggregate); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(aggregate.Files) != 2 || aggregate.Files[1].File != "b.ndjson" {
+		t.Errorf("files = %+v, want a.ndjson and b.ndjson", aggregate.Files)
+	}
+	want := Summary{Errors: 1, Warnings: 1, Fixed: 2}
+	if aggregate.Summary != want {
+		t.Errorf("summary = %+v, want %+v", aggregate.Summary, want)
+	}
+}
+
+func TestWriteTextTotals(t *testing.T) {
+	results := []*checker.Result{
+		{Violations: []checker.Violation{{Severity: checker.SeverityError}}},
+		{Violations: []checker.Violation{{Severity: checker.SeverityInfo}}},
+	}
+
+	var buf bytes.Buffer
+	WriteTextTotals(&buf, results)
+	if want := "\nTotal (2 files): 1 errors, 0 warnings, 1 info\n"; buf.String() != want {
+		t.Errorf("WriteTextTotals() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,180 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+// docsURL is where checkers are documented; each checker's ID is an anchor.
+const docsURL = "https://github.com/michaellady/agents-lint"
+
+// SARIF 2.1.0 types, limited to the properties agents-lint emits.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// WriteSARIF outputs results as a SARIF 2.1.0 log with a single run. Each
+// checker that ran is a rule, and each violation is located at its
+// transcript line. Suppressed violations are included with a SARIF
+// suppression.
+func WriteSARIF(w io.Writer, results ...*checker.Result) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "agents-lint",
+			InformationURI: docsURL,
+			Rules:          make([]sarifReportingDescriptor, 0),
+		}},
+		Results: make([]sarifResult, 0),
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, result := range results {
+		// Violations can also come from the linter itself, such as
+		// ignore markers naming unknown checkers
+		ids := slices.Clone(result.CheckersRun)
+		for _, v := range result.Violations {
+			ids = append(ids, v.CheckerID)
+		}
+		for _, id := range ids {
+			if _, ok := ruleIndex[id]; ok {
+				continue
+			}
+			descriptor := sarifReportingDescriptor{ID: id, HelpURI: docsURL + "#" + id}
+			if c := checker.GetByID(id); c != nil {
+				descriptor.ShortDescription.Text = c.Description()
+			}
+			ruleIndex[id] = len(run.Tool.Driver.Rules)
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, descriptor)
+		}
+	}
+
+	for _, result := range results {
+		for _, v := range result.Violations {
+			run.Results = append(run.Results, sarifResultFor(result.TranscriptPath, v, ruleIndex))
+		}
+		for _, s := range result.Suppressed {
+			r := sarifResultFor(result.TranscriptPath, s.Violation, ruleIndex)
+			kind := "external"
+			if s.Suppression.Source == "transcript" {
+				kind = "inSource"
+			}
+			r.Suppressions = []sarifSuppression{{Kind: kind, Justification: s.Suppression.Reason}}
+			run.Results = append(run.Results, r)
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifResultFor converts a violation to a SARIF result.
+func sarifResultFor(path string, v checker.Violation, ruleIndex map[string]int) sarifResult {
+	location := sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)},
+	}
+	if v.Line > 0 {
+		location.Region = &sarifRegion{StartLine: v.Line}
+	}
+
+	r := sarifResult{
+		RuleID:    v.CheckerID,
+		RuleIndex: ruleIndex[v.CheckerID],
+		Level:     sarifLevel(v.Severity),
+		Message:   sarifMessage{Text: v.Message},
+		Locations: []sarifLocation{{PhysicalLocation: location}},
+		Properties: map[string]string{
+			"rule": v.Rule,
+		},
+	}
+	if v.Agent != "" {
+		r.Properties["agent"] = v.Agent
+	}
+	if v.ToolCallID != "" {
+		r.Properties["toolCallId"] = v.ToolCallID
+	}
+	return r
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s checker.Severity) string {
+	switch s {
+	case checker.SeverityError:
+		return "error"
+	case checker.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
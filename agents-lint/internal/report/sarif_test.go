@@ -0,0 +1,107 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/michaellady/agents-lint/internal/checker"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	result := &checker.Result{
+		TranscriptPath: "testdata/session.ndjson",
+		CheckersRun:    []string{"no-todowrite", "git-branch"},
+		Violations: []checker.Violation{
+			{CheckerID: "git-branch", Rule: "Rule 3", Severity: checker.SeverityError, Message: "Direct push", ToolCallID: "t1", Line: 7},
+			{CheckerID: "no-todowrite", Rule: "Rule 2", Severity: checker.SeverityInfo, Message: "Session-wide"},
+		},
+		Suppressed: []checker.SuppressedViolation{{
+			Violation:   checker.Violation{CheckerID: "git-branch", Severity: checker.SeverityWarning, Message: "Suppressed", Line: 3},
+			Suppression: checker.Suppression{Source: "transcript", Reason: "greenfield"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, result); err != nil {
+		t.Fatalf("WriteSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version = %q, runs = %d; want 2.1.0 with one run", log.Version, len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].ID != "git-branch" {
+		t.Fatalf("rules = %+v, want no-todowrite and git-branch", run.Tool.Driver.Rules)
+	}
+	if run.Tool.Driver.Rules[1].HelpURI != docsURL+"#git-branch" {
+		t.Errorf("helpUri = %q", run.Tool.Driver.Rules[1].HelpURI)
+	}
+
+	tests := []struct {
+		ruleIndex  int
+		level      string
+		line       int
+		suppressed string
+	}{
+		{1, "error", 7, ""},
+		{0, "note", 0, ""},
+		{1, "warning", 3, "inSource"},
+	}
+	if len(run.Results) != len(tests) {
+		t.Fatalf("len(results) = %d, want %d", len(run.Results), len(tests))
+	}
+	for i, tt := range tests {
+		r := run.Results[i]
+		if r.RuleIndex != tt.ruleIndex || r.Level != tt.level {
+			t.Errorf("result %d: ruleIndex = %d, level = %q; want %d, %q", i, r.RuleIndex, r.Level, tt.ruleIndex, tt.level)
+		}
+		loc := r.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != "testdata/session.ndjson" {
+			t.Errorf("result %d: uri = %q", i, loc.ArtifactLocation.URI)
+		}
+		line := 0
+		if loc.Region != nil {
+			line = loc.Region.StartLine
+		}
+		if line != tt.line {
+			t.Errorf("result %d: startLine = %d, want %d", i, line, tt.line)
+		}
+		kind := ""
+		if len(r.Suppressions) > 0 {
+			kind = r.Suppressions[0].Kind
+		}
+		if kind != tt.suppressed {
+			t.Errorf("result %d: suppression kind = %q, want %q", i, kind, tt.suppressed)
+		}
+	}
+}
+
+func TestWriteSARIF_MultipleFiles(t *testing.T) {
+	results := []*checker.Result{
+		{TranscriptPath: "a.ndjson", CheckersRun: []string{"git-branch"}, Violations: []checker.Violation{{CheckerID: "git-branch"}}},
+		{TranscriptPath: "b.ndjson", CheckersRun: []string{"git-branch"}, Violations: []checker.Violation{{CheckerID: "git-branch"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, results...); err != nil {
+		t.Fatalf("WriteSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 {
+		t.Errorf("len(rules) = %d, want 1", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 || run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI != "b.ndjson" {
+		t.Errorf("results = %+v, want one per file", run.Results)
+	}
+}
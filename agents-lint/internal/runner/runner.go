@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/michaellady/agents-lint/internal/checker"
@@ -175,15 +177,17 @@ func (r *Runner) Run(ctx context.Context, s *scenario.Scenario) *scenario.Result
 	checkResult := checker.RunAll(t)
 	result.ViolationCount = len(checkResult.Violations)
 
+	foundViolations := make(map[string]bool)
+	for _, v := range checkResult.Violations {
+		foundViolations[v.CheckerID] = true
+	}
+	result.FoundViolations = slices.Sorted(maps.Keys(foundViolations))
+
 	// Determine if passed based on expectations
 	if s.ExpectPass {
 		result.Passed = !checkResult.HasErrors()
 	} else if len(s.ExpectViolations) > 0 {
 		// Check that expected violations were found
-		foundViolations := make(map[string]bool)
-		for _, v := range checkResult.Violations {
-			foundViolations[v.CheckerID] = true
-		}
 		result.Passed = true
 		for _, expected := range s.ExpectViolations {
 			if !foundViolations[expected] {
@@ -60,6 +60,10 @@ type Result struct {
 	// Violations found during checking.
 	ViolationCount int
 
+	// FoundViolations lists the IDs of checkers that found violations,
+	// sorted.
+	FoundViolations []string
+
 	// Error if the scenario failed to run.
 	Error error
 
@@ -0,0 +1,131 @@
+package transcript
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+// TaskTool is the name of the tool that spawns subagents.
+const TaskTool = "Task"
+
+// Agent is the scope of one agent within a session: either the main agent
+// or a subagent spawned by a Task tool call. Agents form a tree rooted at
+// the main agent, mirroring how Task calls nest.
+type Agent struct {
+	// Task is the Task tool call that spawned this agent (nil for the main agent).
+	Task *ToolCall
+
+	// Type is the subagent_type from the Task input (empty for the main agent).
+	Type string
+
+	// Parent is the agent that issued the Task call (nil for the main agent).
+	Parent *Agent
+
+	// Children are subagents spawned by this agent, in call order.
+	Children []*Agent
+
+	// Events are the assistant and user events produced within this scope.
+	Events []any
+
+	// ToolCalls are the tool calls made by this agent, in order.
+	ToolCalls []ToolCall
+}
+
+// IsMain reports whether this is the top-level agent.
+func (a *Agent) IsMain() bool {
+	return a.Task == nil
+}
+
+// ID returns the Task tool_use ID that spawned this agent, or "main".
+func (a *Agent) ID() string {
+	if a.Task == nil {
+		return "main"
+	}
+	return a.Task.ID
+}
+
+// Name returns a human-readable label such as "main" or "general-purpose (toolu_01)".
+func (a *Agent) Name() string {
+	if a.Task == nil {
+		return "main"
+	}
+	if a.Type == "" {
+		return a.Task.ID
+	}
+	return a.Type + " (" + a.Task.ID + ")"
+}
+
+// All iterates over this agent and its descendants in depth-first order.
+func (a *Agent) All() iter.Seq[*Agent] {
+	return func(yield func(*Agent) bool) {
+		a.walk(yield)
+	}
+}
+
+func (a *Agent) walk(yield func(*Agent) bool) bool {
+	if !yield(a) {
+		return false
+	}
+	for _, child := range a.Children {
+		if !child.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// AgentTree groups the transcript's events and tool calls by the agent
+// that produced them, using parent_tool_use_id to link subagent activity
+// to the Task call that spawned it. Activity whose parent is unknown is
+// attributed to the main agent.
+func (t *Transcript) AgentTree() *Agent {
+	root := &Agent{}
+	byTask := map[string]*Agent{}
+
+	scope := func(parentID string) *Agent {
+		if a, ok := byTask[parentID]; ok {
+			return a
+		}
+		return root
+	}
+
+	for i := range t.ToolCalls {
+		tc := &t.ToolCalls[i]
+		owner := scope(tc.ParentToolUseID)
+		owner.ToolCalls = append(owner.ToolCalls, *tc)
+
+		if tc.Name != TaskTool {
+			continue
+		}
+		var input struct {
+			SubagentType string `json:"subagent_type"`
+		}
+		_ = json.Unmarshal(tc.Input, &input)
+
+		child := &Agent{Task: tc, Type: input.SubagentType, Parent: owner}
+		owner.Children = append(owner.Children, child)
+		byTask[tc.ID] = child
+	}
+
+	for _, event := range t.Events {
+		owner := scope(eventParentToolUseID(event))
+		owner.Events = append(owner.Events, event)
+	}
+
+	return root
+}
+
+// eventParentToolUseID returns the Task tool_use ID an event belongs to, if any.
+func eventParentToolUseID(event any) string {
+	var parent *string
+	switch ev := event.(type) {
+	case AssistantEvent:
+		parent = ev.ParentToolUseID
+	case UserEvent:
+		parent = ev.ParentToolUseID
+	}
+	if parent == nil {
+		return ""
+	}
+	return *parent
+}
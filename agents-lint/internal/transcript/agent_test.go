@@ -0,0 +1,128 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgentTree_StreamJSON(t *testing.T) {
+	data := `{"type":"system","subtype":"init","cwd":"/test","session_id":"s1","tools":["Task","Edit","Bash"],"model":"test","uuid":"u1"}
+{"type":"assistant","message":{"id":"m1","role":"assistant","content":[{"type":"tool_use","id":"task-1","name":"Task","input":{"subagent_type":"general-purpose","prompt":"do it"}}]},"parent_tool_use_id":null,"session_id":"s1","uuid":"u2"}
+{"type":"assistant","message":{"id":"m2","role":"assistant","content":[{"type":"tool_use","id":"edit-1","name":"Edit","input":{"file_path":"/test/a.go"}}]},"parent_tool_use_id":"task-1","session_id":"s1","uuid":"u3"}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"edit-1","content":"ok"}]},"parent_tool_use_id":"task-1","session_id":"s1","uuid":"u4"}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"task-1","content":"done"}]},"parent_tool_use_id":null,"session_id":"s1","uuid":"u5"}
+{"type":"assistant","message":{"id":"m3","role":"assistant","content":[{"type":"tool_use","id":"bash-1","name":"Bash","input":{"command":"git commit -m x"}}]},"parent_tool_use_id":null,"session_id":"s1","uuid":"u6"}`
+
+	tr, err := ParseBytes([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseBytes failed: %v", err)
+	}
+
+	if got := tr.ToolCalls[1].ParentToolUseID; got != "task-1" {
+		t.Errorf("ToolCalls[1].ParentToolUseID = %q, want %q", got, "task-1")
+	}
+
+	root := tr.AgentTree()
+	if !root.IsMain() || root.Name() != "main" {
+		t.Errorf("root = %q, want main agent", root.Name())
+	}
+	if len(root.ToolCalls) != 2 {
+		t.Errorf("main agent has %d tool calls, want 2 (Task, Bash)", len(root.ToolCalls))
+	}
+	if len(root.Children) != 1 {
+		t.Fatalf("main agent has %d children, want 1", len(root.Children))
+	}
+
+	sub := root.Children[0]
+	if sub.Type != "general-purpose" || sub.ID() != "task-1" || sub.Parent != root {
+		t.Errorf("subagent = %+v, want general-purpose spawned by main via task-1", sub)
+	}
+	if sub.Name() != "general-purpose (task-1)" {
+		t.Errorf("Name() = %q, want %q", sub.Name(), "general-purpose (task-1)")
+	}
+	if len(sub.ToolCalls) != 1 || sub.ToolCalls[0].Name != "Edit" {
+		t.Errorf("subagent tool calls = %+v, want single Edit", sub.ToolCalls)
+	}
+	// The Edit's result is kept in its ToolCall, not as an event
+	if len(sub.Events) != 1 {
+		t.Errorf("subagent has %d events, want 1", len(sub.Events))
+	}
+
+	var names []string
+	for a := range root.All() {
+		names = append(names, a.Name())
+	}
+	if strings.Join(names, ",") != "main,general-purpose (task-1)" {
+		t.Errorf("All() = %v", names)
+	}
+}
+
+func TestAgentTree_SessionLogSidechain(t *testing.T) {
+	data := `{"parentUuid":null,"isSidechain":false,"sessionId":"s1","cwd":"/test","type":"assistant","message":{"id":"m1","role":"assistant","model":"test","content":[{"type":"tool_use","id":"task-1","name":"Task","input":{"subagent_type":"Explore","prompt":"look"}}]},"uuid":"u1"}
+{"parentUuid":null,"isSidechain":true,"sessionId":"s1","cwd":"/test","type":"user","message":{"role":"user","content":"look"},"uuid":"u2"}
+{"parentUuid":"u2","isSidechain":true,"sessionId":"s1","cwd":"/test","type":"assistant","message":{"id":"m2","role":"assistant","model":"test","content":[{"type":"tool_use","id":"read-1","name":"Read","input":{"file_path":"/test/a.go"}}]},"uuid":"u3"}
+{"parentUuid":"u3","isSidechain":true,"sessionId":"s1","cwd":"/test","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"read-1","content":"x"}]},"uuid":"u4"}
+{"parentUuid":"u1","isSidechain":false,"sessionId":"s1","cwd":"/test","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"task-1","content":"found"}]},"uuid":"u5"}
+{"parentUuid":"u5","isSidechain":false,"sessionId":"s1","cwd":"/test","type":"assistant","message":{"id":"m3","role":"assistant","model":"test","content":[{"type":"tool_use","id":"read-2","name":"Read","input":{"file_path":"/test/b.go"}}]},"uuid":"u6"}`
+
+	tr, err := ParseBytes([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseBytes failed: %v", err)
+	}
+
+	root := tr.AgentTree()
+	if len(root.Children) != 1 {
+		t.Fatalf("main agent has %d children, want 1", len(root.Children))
+	}
+	sub := root.Children[0]
+	if len(sub.ToolCalls) != 1 || sub.ToolCalls[0].ID != "read-1" {
+		t.Errorf("sidechain tool calls = %+v, want read-1", sub.ToolCalls)
+	}
+	if len(root.ToolCalls) != 2 || root.ToolCalls[1].ID != "read-2" {
+		t.Errorf("main tool calls = %+v, want task-1 then read-2", root.ToolCalls)
+	}
+}
+
+func TestAgentTree_SessionLogParallelSidechains(t *testing.T) {
+	// Two Task calls in one message; their sidechains interleave
+	data := `{"parentUuid":null,"isSidechain":false,"sessionId":"s1","cwd":"/r","type":"assistant","message":{"id":"m1","role":"assistant","model":"test","content":[{"type":"tool_use","id":"T1","name":"Task","input":{"subagent_type":"general-purpose","prompt":"Implement A-1 in ../r-A-1"}},{"type":"tool_use","id":"T2","name":"Task","input":{"subagent_type":"general-purpose","prompt":"Implement A-2 in ../r-A-2"}}]},"uuid":"u1"}
+{"parentUuid":null,"isSidechain":true,"sessionId":"s1","cwd":"/r","type":"user","message":{"role":"user","content":"Implement A-1 in ../r-A-1"},"uuid":"a1"}
+{"parentUuid":null,"isSidechain":true,"sessionId":"s1","cwd":"/r","type":"user","message":{"role":"user","content":"Implement A-2 in ../r-A-2"},"uuid":"b1"}
+{"parentUuid":"b1","isSidechain":true,"sessionId":"s1","cwd":"/r","type":"assistant","message":{"id":"m3","role":"assistant","model":"test","content":[{"type":"tool_use","id":"b-bash","name":"Bash","input":{"command":"cd ../r-A-2"}}]},"uuid":"b2"}
+{"parentUuid":"a1","isSidechain":true,"sessionId":"s1","cwd":"/r","type":"assistant","message":{"id":"m2","role":"assistant","model":"test","content":[{"type":"tool_use","id":"a-bash","name":"Bash","input":{"command":"cd ../r-A-1"}}]},"uuid":"a2"}
+{"parentUuid":"b2","isSidechain":true,"sessionId":"s1","cwd":"/r","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"b-bash","content":""}]},"uuid":"b3"}
+{"parentUuid":"a2","isSidechain":true,"sessionId":"s1","cwd":"/r","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"a-bash","content":""}]},"uuid":"a3"}
+{"parentUuid":"a3","isSidechain":true,"sessionId":"s1","cwd":"/r","type":"assistant","message":{"id":"m4","role":"assistant","model":"test","content":[{"type":"tool_use","id":"a-edit","name":"Edit","input":{"file_path":"/r-A-1/x.go"}}]},"uuid":"a4"}`
+
+	tr, err := ParseBytes([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseBytes failed: %v", err)
+	}
+
+	want := map[string]string{"b-bash": "T2", "a-bash": "T1", "a-edit": "T1"}
+	for _, tc := range tr.ToolCalls {
+		if parent, ok := want[tc.ID]; ok && tc.ParentToolUseID != parent {
+			t.Errorf("%s parent = %q, want %q", tc.ID, tc.ParentToolUseID, parent)
+		}
+	}
+
+	root := tr.AgentTree()
+	if len(root.Children) != 2 || len(root.Children[0].ToolCalls) != 2 || len(root.Children[1].ToolCalls) != 1 {
+		t.Errorf("subagent tool calls not split between T1 and T2: %+v", root.Children)
+	}
+}
+
+func TestAgentTree_ManualTranscript(t *testing.T) {
+	// Checker tests build transcripts without events; unknown parents fall back to main
+	tr := &Transcript{
+		ToolCalls: []ToolCall{
+			{ID: "t1", Name: "Edit"},
+			{ID: "t2", Name: "Bash", ParentToolUseID: "missing"},
+		},
+	}
+
+	root := tr.AgentTree()
+	if len(root.ToolCalls) != 2 || len(root.Children) != 0 {
+		t.Errorf("root = %d calls, %d children; want 2 calls, 0 children", len(root.ToolCalls), len(root.Children))
+	}
+}
@@ -0,0 +1,89 @@
+package transcript
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Stdin is the path argument that reads a transcript from standard input.
+const Stdin = "-"
+
+// extensions are the file extensions of transcripts found in directories.
+var extensions = []string{".ndjson", ".jsonl"}
+
+// FindFiles expands command-line arguments into transcript paths.
+// Directories are searched recursively for *.ndjson and *.jsonl files,
+// glob patterns are expanded, and Stdin and plain file paths are kept as
+// given. Each path is returned once, in argument order.
+func FindFiles(args []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, arg := range args {
+		if arg == Stdin {
+			add(arg)
+			continue
+		}
+
+		matches := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			var err error
+			matches, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("bad pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || !info.IsDir() {
+				// Missing files are reported when they are parsed
+				add(match)
+				continue
+			}
+
+			found, err := findInDir(match)
+			if err != nil {
+				return nil, err
+			}
+			if len(found) == 0 {
+				return nil, fmt.Errorf("no transcripts in %s", match)
+			}
+			for _, path := range found {
+				add(path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// findInDir returns the transcripts under dir in lexical order.
+func findInDir(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && slices.Contains(extensions, filepath.Ext(path)) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search %s: %w", dir, err)
+	}
+	return paths, nil
+}
@@ -0,0 +1,60 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.ndjson", "sub/b.jsonl", "sub/deep/c.ndjson", "notes.txt", "other/d.ndjson"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"file", []string{join("a.ndjson")}, []string{join("a.ndjson")}},
+		{"directory", []string{join("sub")}, []string{join("sub/b.jsonl"), join("sub/deep/c.ndjson")}},
+		{"glob", []string{join("*/d.*")}, []string{join("other/d.ndjson")}},
+		{"stdin", []string{"-"}, []string{"-"}},
+		{"missing file kept", []string{join("missing.ndjson")}, []string{join("missing.ndjson")}},
+		{"duplicates removed", []string{join("a.ndjson"), join("*.ndjson")}, []string{join("a.ndjson")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindFiles(tt.args)
+			if err != nil {
+				t.Fatalf("FindFiles() error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFiles_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, args := range [][]string{
+		{filepath.Join(dir, "*.ndjson")}, // no match
+		{dir},                            // no transcripts
+	} {
+		if _, err := FindFiles(args); err == nil {
+			t.Errorf("FindFiles(%v) succeeded, want error", args)
+		}
+	}
+}
@@ -2,10 +2,15 @@
 package transcript
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"time"
 )
 
 // ParseFile reads and parses an NDJSON transcript file.
@@ -16,130 +21,276 @@ func ParseFile(path string) (*Transcript, error) {
 	}
 	defer f.Close()
 
-	var lines [][]byte
-	scanner := bufio.NewScanner(f)
-	// Increase buffer size for large tool outputs
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-		// Make a copy since scanner reuses the buffer
-		lineCopy := make([]byte, len(line))
-		copy(lineCopy, line)
-		lines = append(lines, lineCopy)
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("read transcript: %w", err)
-	}
-
-	return parseLines(lines)
+	return Parse(f)
 }
 
 // ParseBytes parses NDJSON transcript data from a byte slice.
 func ParseBytes(data []byte) (*Transcript, error) {
-	var lines [][]byte
-	start := 0
-	for i := 0; i < len(data); i++ {
-		if data[i] == '\n' {
-			if i > start {
-				lines = append(lines, data[start:i])
-			}
-			start = i + 1
+	return Parse(bytes.NewReader(data))
+}
+
+// Parse reads every event from r and builds a Transcript.
+// Both stream-json output and Claude Code session logs are accepted;
+// the format is detected from the events themselves. See Builder for how
+// much of the session is held in memory.
+func Parse(r io.Reader) (*Transcript, error) {
+	rd := NewReader(r)
+	b := NewBuilder()
+	for ev, err := range rd.Events() {
+		if err != nil {
+			return nil, err
 		}
-	}
-	if start < len(data) {
-		lines = append(lines, data[start:])
+		b.Add(ev)
 	}
 
-	return parseLines(lines)
+	t, err := b.Transcript()
+	if err != nil {
+		return nil, err
+	}
+	t.Format = rd.Format()
+	return t, nil
 }
 
-// parseLines parses individual NDJSON lines into a Transcript.
-func parseLines(lines [][]byte) (*Transcript, error) {
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("empty transcript")
-	}
+// Builder assembles a Transcript one event at a time.
+// Tool calls are appended when their tool_use block is seen and completed
+// when the matching tool_result arrives.
+//
+// The Transcript holds the whole session, so memory grows with it, but only
+// what checkers read is retained: event metadata, message text, and tool
+// calls. Each tool input and result is kept once, in ToolCalls. Retained
+// tool_use blocks drop their input, matched tool_result blocks are dropped
+// along with the session log toolUseResult summary, and user events left
+// empty are not retained, nor are events of unknown types.
+type Builder struct {
+	t *Transcript
 
-	t := &Transcript{
-		Events: make([]any, 0, len(lines)),
-	}
+	// pending maps tool_use IDs to their index in t.ToolCalls
+	pending map[string]int
 
-	// Map tool_use IDs to their results for building ToolCalls
-	toolResults := make(map[string]struct {
-		result  string
-		isError bool
-	})
+	// openTasks are Task tool_use IDs still awaiting their result, innermost last
+	openTasks []string
 
-	for i, line := range lines {
-		// First, parse just the type field to determine event type
-		var base Event
-		if err := json.Unmarshal(line, &base); err != nil {
-			return nil, fmt.Errorf("line %d: parse event type: %w", i+1, err)
-		}
+	// taskPrompts maps open Task tool_use IDs to their prompts until a
+	// session log sidechain starting with that prompt claims them
+	taskPrompts map[string]string
 
-		switch base.Type {
-		case "system":
-			var ev SystemEvent
-			if err := json.Unmarshal(line, &ev); err != nil {
-				return nil, fmt.Errorf("line %d: parse system event: %w", i+1, err)
-			}
+	// sidechains maps session log sidechain event UUIDs to their Task call
+	sidechains map[string]string
+
+	// added counts the events added, retained or not
+	added int
+
+	// lastDone maps an agent (by parent tool_use ID) to when its most
+	// recent tool call completed, for computing inter-call gaps
+	lastDone map[string]time.Time
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{
+		t:           &Transcript{},
+		pending:     make(map[string]int),
+		taskPrompts: make(map[string]string),
+		sidechains:  make(map[string]string),
+		lastDone:    make(map[string]time.Time),
+	}
+}
+
+// Add records a single event decoded by Reader.
+func (b *Builder) Add(event any) {
+	t := b.t
+	b.added++
+
+	switch ev := event.(type) {
+	case SystemEvent:
+		if ev.Subtype == "init" {
 			t.SessionID = ev.SessionID
 			t.Model = ev.Model
 			t.CWD = ev.CWD
 			t.Tools = ev.Tools
-			t.Events = append(t.Events, ev)
+		}
+		b.addSessionInfo(ev.Event)
 
-		case "assistant":
-			var ev AssistantEvent
-			if err := json.Unmarshal(line, &ev); err != nil {
-				return nil, fmt.Errorf("line %d: parse assistant event: %w", i+1, err)
-			}
-			t.Events = append(t.Events, ev)
+	case AssistantEvent:
+		// Session logs have no init event; take the model from the first reply
+		if t.Model == "" && ev.Message.Model != "<synthetic>" {
+			t.Model = ev.Message.Model
+		}
+		b.addSessionInfo(ev.Event)
+		ev.ParentToolUseID = b.parentToolUseID(ev.Event, ev.ParentToolUseID, "")
+
+		// Inputs are kept only in ToolCalls; copy the content so the
+		// caller's event is left as it was
+		ev.Message.Content = slices.Clone(ev.Message.Content)
+		event = ev
 
-		case "user":
-			var ev UserEvent
-			if err := json.Unmarshal(line, &ev); err != nil {
-				return nil, fmt.Errorf("line %d: parse user event: %w", i+1, err)
+		for i, content := range ev.Message.Content {
+			if content.Type != "tool_use" {
+				continue
+			}
+			ev.Message.Content[i].Input = nil
+			b.pending[content.ID] = len(t.ToolCalls)
+			tc := ToolCall{
+				ID:        content.ID,
+				Name:      content.Name,
+				Input:     content.Input,
+				EventUUID: ev.UUID,
+				Line:      ev.Line,
+				Offset:    ev.Offset,
+				Timestamp: ev.Timestamp,
 			}
-			// Store tool results for later matching
-			for _, content := range ev.Message.Content {
-				if content.Type == "tool_result" && content.ToolUseID != "" {
-					// Content can be string or array - extract string representation
-					contentStr := extractContentString(content.Content)
-					toolResults[content.ToolUseID] = struct {
-						result  string
-						isError bool
-					}{contentStr, content.IsError}
+			if ev.ParentToolUseID != nil {
+				tc.ParentToolUseID = *ev.ParentToolUseID
+			}
+			if done, ok := b.lastDone[tc.ParentToolUseID]; ok && !tc.Timestamp.IsZero() {
+				tc.Gap = max(tc.Timestamp.Sub(done), 0)
+			}
+			t.ToolCalls = append(t.ToolCalls, tc)
+			if content.Name == TaskTool {
+				b.openTasks = append(b.openTasks, content.ID)
+				var input struct {
+					Prompt string `json:"prompt"`
+				}
+				if json.Unmarshal(content.Input, &input) == nil && input.Prompt != "" {
+					b.taskPrompts[content.ID] = strings.TrimSpace(input.Prompt)
 				}
 			}
-			t.Events = append(t.Events, ev)
+		}
+
+	case UserEvent:
+		b.addSessionInfo(ev.Event)
+		var text []string
+		for _, content := range ev.Message.Content {
+			if content.Type == "text" {
+				text = append(text, content.Text)
+			}
+		}
+		ev.ParentToolUseID = b.parentToolUseID(ev.Event, ev.ParentToolUseID, strings.Join(text, "\n"))
 
-		case "result":
-			var ev ResultEvent
-			if err := json.Unmarshal(line, &ev); err != nil {
-				return nil, fmt.Errorf("line %d: parse result event: %w", i+1, err)
+		// Match tool results with their pending tool calls. Once matched,
+		// a result is kept only in its ToolCall.
+		var kept []UserContentBlock
+		matched := false
+		for _, content := range ev.Message.Content {
+			idx, ok := b.pending[content.ToolUseID]
+			if content.Type != "tool_result" || content.ToolUseID == "" || !ok {
+				kept = append(kept, content)
+				continue
+			}
+			// Content can be string or array - extract string representation
+			t.ToolCalls[idx].Result = extractContentString(content.Content)
+			t.ToolCalls[idx].IsError = content.IsError
+			b.recordCompletion(&t.ToolCalls[idx], ev.Timestamp)
+			delete(b.pending, content.ToolUseID)
+			b.closeTask(content.ToolUseID)
+			matched = true
+		}
+		if matched {
+			if len(kept) == 0 {
+				return
 			}
-			t.TotalCostUSD = ev.TotalCostUSD
-			t.NumTurns = ev.NumTurns
-			t.IsError = ev.IsError
-			t.Result = ev.Result
-			t.Events = append(t.Events, ev)
-
-		default:
-			// Store unknown events as raw JSON
-			var raw json.RawMessage = line
-			t.Events = append(t.Events, raw)
+			ev.Message.Content = kept
+			ev.ToolUseResult = nil
+		}
+		event = ev
+
+	case ResultEvent:
+		t.TotalCostUSD = ev.TotalCostUSD
+		t.NumTurns = ev.NumTurns
+		t.IsError = ev.IsError
+		t.Result = ev.Result
+
+	case json.RawMessage:
+		// Checkers don't read events of unknown types
+		return
+	}
+
+	t.Events = append(t.Events, event)
+}
+
+// addSessionInfo fills session-level fields that session logs repeat on
+// every entry instead of announcing once in a system/init event.
+func (b *Builder) addSessionInfo(ev Event) {
+	if b.t.SessionID == "" {
+		b.t.SessionID = ev.SessionID
+	}
+	if b.t.CWD == "" {
+		b.t.CWD = ev.CWD
+	}
+}
+
+// parentToolUseID returns the Task call an event belongs to. Stream-json
+// sets parent_tool_use_id explicitly; session logs only mark subagent
+// entries with isSidechain. A sidechain starts with a user message whose
+// text is the Task prompt, and its later entries link back to it through
+// parentUuid, so parallel Task calls are told apart. Sidechain entries
+// that can't be traced are attributed to the innermost open Task call.
+func (b *Builder) parentToolUseID(ev Event, parent *string, text string) *string {
+	if parent != nil || !ev.IsSidechain {
+		return parent
+	}
+
+	id, ok := b.sidechains[ev.ParentUUID]
+	if !ok && text != "" {
+		id, ok = b.claimTask(strings.TrimSpace(text))
+	}
+	if !ok && len(b.openTasks) > 0 {
+		id, ok = b.openTasks[len(b.openTasks)-1], true
+	}
+	if !ok {
+		return nil
+	}
+	if ev.UUID != "" {
+		b.sidechains[ev.UUID] = id
+	}
+	return &id
+}
+
+// claimTask returns the first open Task call with the given prompt that
+// no sidechain has claimed yet.
+func (b *Builder) claimTask(prompt string) (string, bool) {
+	for _, id := range b.openTasks {
+		if p, ok := b.taskPrompts[id]; ok && p == prompt {
+			delete(b.taskPrompts, id)
+			return id, true
 		}
 	}
+	return "", false
+}
 
-	// Extract tool calls from assistant events
-	t.ToolCalls = ExtractToolCalls(t, toolResults)
+// recordCompletion stores when a tool call's result arrived and derives its latency.
+func (b *Builder) recordCompletion(tc *ToolCall, at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	tc.ResultTimestamp = at
+	if !tc.Timestamp.IsZero() {
+		tc.Latency = max(at.Sub(tc.Timestamp), 0)
+	}
+	if at.After(b.lastDone[tc.ParentToolUseID]) {
+		b.lastDone[tc.ParentToolUseID] = at
+	}
+}
 
-	return t, nil
+// closeTask removes a Task call from the open set once its result arrives.
+func (b *Builder) closeTask(id string) {
+	for i, open := range b.openTasks {
+		if open == id {
+			b.openTasks = append(b.openTasks[:i], b.openTasks[i+1:]...)
+			delete(b.taskPrompts, id)
+			maps.DeleteFunc(b.sidechains, func(_, task string) bool { return task == id })
+			return
+		}
+	}
+}
+
+// Transcript returns the assembled transcript.
+// It returns an error if no events have been added.
+func (b *Builder) Transcript() (*Transcript, error) {
+	if b.added == 0 {
+		return nil, fmt.Errorf("empty transcript")
+	}
+	return b.t, nil
 }
 
 // extractContentString extracts string content from raw JSON that can be a string or array.
@@ -175,41 +326,3 @@ func extractContentString(raw json.RawMessage) string {
 	// Return as-is if neither works
 	return string(raw)
 }
-
-// ExtractToolCalls extracts all tool calls from a transcript with their results.
-func ExtractToolCalls(t *Transcript, toolResults map[string]struct {
-	result  string
-	isError bool
-}) []ToolCall {
-	var calls []ToolCall
-
-	for _, event := range t.Events {
-		assitantEv, ok := event.(AssistantEvent)
-		if !ok {
-			continue
-		}
-
-		for _, content := range assitantEv.Message.Content {
-			if content.Type != "tool_use" {
-				continue
-			}
-
-			tc := ToolCall{
-				ID:        content.ID,
-				Name:      content.Name,
-				Input:     content.Input,
-				EventUUID: assitantEv.UUID,
-			}
-
-			// Match with result if available
-			if res, ok := toolResults[content.ID]; ok {
-				tc.Result = res.result
-				tc.IsError = res.isError
-			}
-
-			calls = append(calls, tc)
-		}
-	}
-
-	return calls
-}
@@ -1,8 +1,10 @@
 package transcript
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseFile(t *testing.T) {
@@ -37,9 +39,9 @@ func TestParseFile(t *testing.T) {
 		t.Errorf("TotalCostUSD = %f, want 0.001", transcript.TotalCostUSD)
 	}
 
-	// Verify events count
-	if len(transcript.Events) != 5 {
-		t.Errorf("len(Events) = %d, want 5", len(transcript.Events))
+	// Verify events count; the tool result event isn't retained
+	if len(transcript.Events) != 4 {
+		t.Errorf("len(Events) = %d, want 4", len(transcript.Events))
 	}
 
 	// Verify tool calls extraction
@@ -122,4 +124,124 @@ func TestToolCallWithError(t *testing.T) {
 	if tc.Result != "command failed" {
 		t.Errorf("ToolCall.Result = %q, want %q", tc.Result, "command failed")
 	}
+	if tc.Line != 2 {
+		t.Errorf("ToolCall.Line = %d, want 2", tc.Line)
+	}
+	if want := int64(bytes.IndexByte(data, '\n') + 1); tc.Offset != want {
+		t.Errorf("ToolCall.Offset = %d, want %d", tc.Offset, want)
+	}
+}
+
+func TestParseFile_SessionLog(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "transcripts", "passing", "session-log.jsonl")
+	transcript, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if transcript.Format != FormatSessionLog {
+		t.Errorf("Format = %v, want %v", transcript.Format, FormatSessionLog)
+	}
+	if transcript.SessionID != "test-session-log" {
+		t.Errorf("SessionID = %q, want %q", transcript.SessionID, "test-session-log")
+	}
+	if transcript.CWD != "/Users/test/project" {
+		t.Errorf("CWD = %q, want %q", transcript.CWD, "/Users/test/project")
+	}
+	if transcript.Model != "claude-sonnet-4-20250514" {
+		t.Errorf("Model = %q, want %q", transcript.Model, "claude-sonnet-4-20250514")
+	}
+
+	// summary + 3 conversation entries; the tool result is kept only in
+	// its ToolCall
+	if len(transcript.Events) != 4 {
+		t.Errorf("len(Events) = %d, want 4", len(transcript.Events))
+	}
+	if _, ok := transcript.Events[0].(SummaryEvent); !ok {
+		t.Errorf("Events[0] = %T, want SummaryEvent", transcript.Events[0])
+	}
+
+	// Typed prompts are plain strings in session logs
+	prompt, ok := transcript.Events[1].(UserEvent)
+	if !ok {
+		t.Fatalf("Events[1] = %T, want UserEvent", transcript.Events[1])
+	}
+	if len(prompt.Message.Content) != 1 || prompt.Message.Content[0].Text != "What does main.go do?" {
+		t.Errorf("prompt content = %+v, want single text block", prompt.Message.Content)
+	}
+
+	for _, event := range transcript.Events {
+		if ev, ok := event.(UserEvent); ok && ev.UUID == "uuid-3" {
+			t.Errorf("tool result event retained: %+v", ev)
+		}
+	}
+
+	if len(transcript.ToolCalls) != 1 {
+		t.Fatalf("len(ToolCalls) = %d, want 1", len(transcript.ToolCalls))
+	}
+	tc := transcript.ToolCalls[0]
+	if tc.Name != "Read" || tc.EventUUID != "uuid-2" {
+		t.Errorf("ToolCall = %+v, want Read from uuid-2", tc)
+	}
+	if tc.Result != "package main\n\nfunc main() {}" {
+		t.Errorf("ToolCall.Result = %q, want file content", tc.Result)
+	}
+}
+
+func TestParseFile_StreamJSONFormat(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "transcripts", "passing", "simple.ndjson")
+	transcript, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if transcript.Format != FormatStreamJSON {
+		t.Errorf("Format = %v, want %v", transcript.Format, FormatStreamJSON)
+	}
+}
+
+func TestParseBytes_ToolCallTiming(t *testing.T) {
+	data := []byte(`{"parentUuid":null,"sessionId":"s1","type":"assistant","message":{"id":"m1","role":"assistant","model":"test","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"gh pr checks"}}]},"uuid":"u1","timestamp":"2025-06-01T10:00:00.000Z"}
+{"parentUuid":"u1","sessionId":"s1","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"pending"}]},"uuid":"u2","timestamp":"2025-06-01T10:00:01.500Z"}
+{"parentUuid":"u2","sessionId":"s1","type":"assistant","message":{"id":"m2","role":"assistant","model":"test","content":[{"type":"tool_use","id":"t2","name":"BashOutput","input":{"bash_id":"b1"}}]},"uuid":"u3","timestamp":"2025-06-01T10:00:11.500Z"}
+{"parentUuid":"u3","sessionId":"s1","type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"pass"}]},"uuid":"u4","timestamp":"not a time"}`)
+
+	transcript, err := ParseBytes(data)
+	if err != nil {
+		t.Fatalf("ParseBytes failed: %v", err)
+	}
+	if len(transcript.ToolCalls) != 2 {
+		t.Fatalf("len(ToolCalls) = %d, want 2", len(transcript.ToolCalls))
+	}
+
+	first := transcript.ToolCalls[0]
+	if first.Timestamp.IsZero() || first.ResultTimestamp.IsZero() {
+		t.Errorf("first call timestamps not set: %+v", first)
+	}
+	if first.Latency != 1500*time.Millisecond {
+		t.Errorf("first.Latency = %v, want 1.5s", first.Latency)
+	}
+	if first.Gap != 0 {
+		t.Errorf("first.Gap = %v, want 0", first.Gap)
+	}
+
+	second := transcript.ToolCalls[1]
+	if second.Gap != 10*time.Second {
+		t.Errorf("second.Gap = %v, want 10s", second.Gap)
+	}
+	// Malformed timestamp is ignored rather than failing the parse
+	if !second.ResultTimestamp.IsZero() || second.Latency != 0 {
+		t.Errorf("second call should have no completion time, got %v / %v", second.ResultTimestamp, second.Latency)
+	}
+}
+
+func TestParseFile_StreamJSONHasNoTiming(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "transcripts", "passing", "simple.ndjson")
+	transcript, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	tc := transcript.ToolCalls[0]
+	if !tc.Timestamp.IsZero() || tc.Latency != 0 || tc.Gap != 0 {
+		t.Errorf("stream-json tool call has timing %v/%v/%v, want zero", tc.Timestamp, tc.Latency, tc.Gap)
+	}
 }
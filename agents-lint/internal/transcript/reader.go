@@ -0,0 +1,203 @@
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+)
+
+// Reader decodes transcript events one NDJSON line at a time. It holds
+// only the current line in memory, and lines may be arbitrarily long;
+// Parse, which keeps the session in a Transcript, holds much more.
+type Reader struct {
+	br     *bufio.Reader
+	line   int
+	offset int64 // byte offset of the current line
+	read   int64 // bytes consumed so far
+	err    error
+	format Format
+}
+
+// NewReader returns a Reader that decodes events from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{br: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Line returns the 1-based line number of the most recently decoded event.
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Offset returns the byte offset of the start of the most recently
+// decoded event's line.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Format reports the transcript format detected so far. It is
+// FormatSessionLog once any session log entry has been decoded.
+func (r *Reader) Format() Format {
+	return r.format
+}
+
+// Next returns the next event in the stream. Blank lines are skipped.
+// The event is one of SystemEvent, AssistantEvent, UserEvent, ResultEvent,
+// SummaryEvent, or json.RawMessage for unknown types. Next returns io.EOF when the
+// stream is exhausted; any other error is sticky.
+func (r *Reader) Next() (any, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for {
+		line, err := r.br.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			if !errors.Is(err, io.EOF) {
+				err = fmt.Errorf("read transcript: %w", err)
+			}
+			r.err = err
+			return nil, err
+		}
+		r.line++
+		r.offset = r.read
+		r.read += int64(len(line))
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err != nil {
+				r.err = io.EOF
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		ev, format, decodeErr := decodeEvent(line, r.line, r.offset)
+		if decodeErr != nil {
+			r.err = fmt.Errorf("line %d: %w", r.line, decodeErr)
+			return nil, r.err
+		}
+		if format == FormatSessionLog {
+			r.format = format
+		}
+		return ev, nil
+	}
+}
+
+// Events returns an iterator over the remaining events. Iteration ends at
+// end of stream, or after yielding the first decode error.
+func (r *Reader) Events() iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		for {
+			ev, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(ev, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// envelope holds the fields needed to dispatch a line to its event type.
+// Session logs use camelCase keys where stream-json uses snake_case, so
+// both spellings are captured and normalized onto the typed event.
+type envelope struct {
+	Event
+	SessionLogID  string          `json:"sessionId"`
+	ToolUseResult json.RawMessage `json:"toolUseResult"`
+	Timestamp     string          `json:"timestamp"`
+
+	line   int
+	offset int64
+}
+
+// format reports which transcript format the line came from.
+func (e *envelope) format() Format {
+	if e.SessionLogID != "" || e.Type == "summary" {
+		return FormatSessionLog
+	}
+	return FormatStreamJSON
+}
+
+// normalize copies camelCase session log fields, the timestamp, and the
+// source position onto ev. An unparseable timestamp is dropped rather than
+// failing the whole line.
+func (e *envelope) normalize(ev *Event) {
+	ev.Line = e.line
+	ev.Offset = e.offset
+	if ev.SessionID == "" {
+		ev.SessionID = e.SessionLogID
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+		ev.Timestamp = ts
+	}
+}
+
+// decodeEvent unmarshals a single line into its typed event struct.
+// lineNo and offset are the line's 1-based number and starting byte offset
+// in the transcript.
+func decodeEvent(line []byte, lineNo int, offset int64) (any, Format, error) {
+	// First, parse just the envelope to determine event type and format
+	var env envelope
+	if err := json.Unmarshal(line, &env); err != nil {
+		return nil, 0, fmt.Errorf("parse event type: %w", err)
+	}
+	env.line = lineNo
+	env.offset = offset
+	format := env.format()
+
+	switch env.Type {
+	case "system":
+		var ev SystemEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, format, fmt.Errorf("parse system event: %w", err)
+		}
+		env.normalize(&ev.Event)
+		return ev, format, nil
+
+	case "assistant":
+		var ev AssistantEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, format, fmt.Errorf("parse assistant event: %w", err)
+		}
+		env.normalize(&ev.Event)
+		return ev, format, nil
+
+	case "user":
+		var ev UserEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, format, fmt.Errorf("parse user event: %w", err)
+		}
+		env.normalize(&ev.Event)
+		if len(ev.ToolUseResult) == 0 {
+			ev.ToolUseResult = env.ToolUseResult
+		}
+		return ev, format, nil
+
+	case "result":
+		var ev ResultEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, format, fmt.Errorf("parse result event: %w", err)
+		}
+		env.normalize(&ev.Event)
+		return ev, format, nil
+
+	case "summary":
+		var ev SummaryEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, format, fmt.Errorf("parse summary event: %w", err)
+		}
+		env.normalize(&ev.Event)
+		return ev, format, nil
+
+	default:
+		// Store unknown events as raw JSON
+		return json.RawMessage(line), format, nil
+	}
+}
@@ -0,0 +1,199 @@
+package transcript
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReader_Next(t *testing.T) {
+	data := `{"type":"system","subtype":"init","cwd":"/test","session_id":"s1","tools":[],"model":"test","uuid":"u1"}
+
+{"type":"stream_event","uuid":"u2"}
+{"type":"result","subtype":"success","is_error":false,"num_turns":0,"result":"done","session_id":"s1","uuid":"u3"}`
+
+	r := NewReader(strings.NewReader(data))
+
+	ev, err := r.Next()
+	if err != nil {
+		t.Fatalf("Next() error: %v", err)
+	}
+	if _, ok := ev.(SystemEvent); !ok {
+		t.Errorf("first event = %T, want SystemEvent", ev)
+	}
+	if r.Line() != 1 {
+		t.Errorf("Line() = %d, want 1", r.Line())
+	}
+
+	ev, err = r.Next()
+	if err != nil {
+		t.Fatalf("Next() error: %v", err)
+	}
+	if _, ok := ev.(json.RawMessage); !ok {
+		t.Errorf("unknown event = %T, want json.RawMessage", ev)
+	}
+	if r.Line() != 3 {
+		t.Errorf("Line() = %d, want 3 (blank line skipped)", r.Line())
+	}
+
+	ev, err = r.Next()
+	if err != nil {
+		t.Fatalf("Next() error: %v", err)
+	}
+	if res, ok := ev.(ResultEvent); !ok {
+		t.Errorf("last event = %T, want ResultEvent", ev)
+	} else if res.Line != 4 {
+		t.Errorf("ResultEvent.Line = %d, want 4", res.Line)
+	}
+	if want := int64(strings.Index(data, `{"type":"result"`)); r.Offset() != want {
+		t.Errorf("Offset() = %d, want %d", r.Offset(), want)
+	}
+
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() at end = %v, want io.EOF", err)
+	}
+}
+
+func TestReader_LongLine(t *testing.T) {
+	// Larger than the old 1 MiB scanner limit
+	big := strings.Repeat("x", 3*1024*1024)
+	data := `{"type":"system","subtype":"init","cwd":"/test","session_id":"s1","tools":["Bash"],"model":"test","uuid":"u1"}
+{"type":"assistant","message":{"id":"m1","role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"cat big"}}]},"session_id":"s1","uuid":"u2"}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"` + big + `"}]},"session_id":"s1","uuid":"u3"}
+`
+
+	transcript, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(transcript.ToolCalls) != 1 {
+		t.Fatalf("len(ToolCalls) = %d, want 1", len(transcript.ToolCalls))
+	}
+	if len(transcript.ToolCalls[0].Result) != len(big) {
+		t.Errorf("len(Result) = %d, want %d", len(transcript.ToolCalls[0].Result), len(big))
+	}
+}
+
+func TestReader_Events(t *testing.T) {
+	data := `{"type":"system","subtype":"init","session_id":"s1","uuid":"u1"}
+{"type":"user","message":{"role":"user","content":[{"type":"text","text":"hi"}]},"uuid":"u2"}
+not json
+{"type":"result","subtype":"success","uuid":"u4"}`
+
+	var count int
+	var lastErr error
+	for ev, err := range NewReader(strings.NewReader(data)).Events() {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if ev == nil {
+			t.Error("nil event without error")
+		}
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("decoded %d events before error, want 2", count)
+	}
+	if lastErr == nil || !strings.Contains(lastErr.Error(), "line 3") {
+		t.Errorf("error = %v, want mention of line 3", lastErr)
+	}
+}
+
+func TestBuilder_Incremental(t *testing.T) {
+	b := NewBuilder()
+
+	if _, err := b.Transcript(); err == nil {
+		t.Error("expected error for empty builder")
+	}
+
+	b.Add(AssistantEvent{
+		Event: Event{Type: "assistant", UUID: "u1"},
+		Message: AssistantMessage{Content: []ContentBlock{
+			{Type: "tool_use", ID: "t1", Name: "Bash"},
+		}},
+	})
+
+	tr, err := b.Transcript()
+	if err != nil {
+		t.Fatalf("Transcript() error: %v", err)
+	}
+	if len(tr.ToolCalls) != 1 || tr.ToolCalls[0].Result != "" {
+		t.Fatalf("ToolCalls = %+v, want one pending call", tr.ToolCalls)
+	}
+
+	b.Add(UserEvent{
+		Event: Event{Type: "user", UUID: "u2"},
+		Message: UserMessage{Content: []UserContentBlock{
+			{Type: "tool_result", ToolUseID: "t1", Content: []byte(`"ok"`)},
+		}},
+	})
+
+	if tr.ToolCalls[0].Result != "ok" {
+		t.Errorf("Result = %q, want %q", tr.ToolCalls[0].Result, "ok")
+	}
+	if tr.ToolCalls[0].EventUUID != "u1" {
+		t.Errorf("EventUUID = %q, want %q", tr.ToolCalls[0].EventUUID, "u1")
+	}
+
+	// The matched result isn't kept a second time in Events, and the
+	// tool_use keeps no copy of its input
+	if len(tr.Events) != 1 {
+		t.Fatalf("len(Events) = %d, want 1", len(tr.Events))
+	}
+	if block := tr.Events[0].(AssistantEvent).Message.Content[0]; block.Name != "Bash" || block.Input != nil {
+		t.Errorf("retained tool_use = %+v, want name without input", block)
+	}
+
+	// Results with no pending call, and text, stay in the event
+	b.Add(UserEvent{
+		Event: Event{Type: "user", UUID: "u3"},
+		Message: UserMessage{Content: []UserContentBlock{
+			{Type: "tool_result", ToolUseID: "t9", Content: []byte(`"orphan"`)},
+			{Type: "text", Text: "carry on"},
+		}},
+	})
+	if content := tr.Events[1].(UserEvent).Message.Content; len(content) != 2 || string(content[0].Content) != `"orphan"` {
+		t.Errorf("unmatched event content = %+v, want it kept", content)
+	}
+
+	// Events of unknown types count toward a non-empty transcript but
+	// aren't retained
+	unknown := NewBuilder()
+	unknown.Add(json.RawMessage(`{"type":"progress"}`))
+	if tr, err := unknown.Transcript(); err != nil || len(tr.Events) != 0 {
+		t.Errorf("Transcript() = %v, %v; want no events and no error", tr, err)
+	}
+}
+
+func TestReader_SessionLogToolUseResult(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "transcripts", "passing", "session-log.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for ev, err := range NewReader(f).Events() {
+		if err != nil {
+			t.Fatalf("Events() error: %v", err)
+		}
+		if user, ok := ev.(UserEvent); ok && user.UUID == "uuid-3" {
+			if user.ParentUUID != "uuid-2" {
+				t.Errorf("ParentUUID = %q, want %q", user.ParentUUID, "uuid-2")
+			}
+			if user.GitBranch != "AGENTS-42" {
+				t.Errorf("GitBranch = %q, want %q", user.GitBranch, "AGENTS-42")
+			}
+			if len(user.ToolUseResult) == 0 {
+				t.Error("ToolUseResult not normalized from toolUseResult")
+			}
+			return
+		}
+	}
+	t.Error("tool result event uuid-3 not found")
+}
@@ -1,21 +1,60 @@
 // Package transcript defines types for parsing Claude Code stream-json transcripts.
 package transcript
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
-// Event represents a single NDJSON line from stream-json output.
+// Format identifies which Claude Code output a transcript was read from.
+type Format int
+
+const (
+	// FormatStreamJSON is the output of `claude -p --output-format stream-json`.
+	FormatStreamJSON Format = iota
+	// FormatSessionLog is the per-project session log Claude Code writes to
+	// ~/.claude/projects/<slug>/<session>.jsonl.
+	FormatSessionLog
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatStreamJSON:
+		return "stream-json"
+	case FormatSessionLog:
+		return "session-log"
+	default:
+		return "unknown"
+	}
+}
+
+// Event represents a single NDJSON line from stream-json output or a session log.
 // The Type field determines which specific event struct to unmarshal into.
 type Event struct {
 	Type      string `json:"type"`                 // "system", "assistant", "user", "result"
 	Subtype   string `json:"subtype,omitempty"`    // "init" for system, "success"/"error" for result
 	SessionID string `json:"session_id,omitempty"` // Unique session identifier
 	UUID      string `json:"uuid,omitempty"`       // Unique event identifier
+	CWD       string `json:"cwd,omitempty"`        // Working directory (system init, or every session log entry)
+
+	// Timestamp is when the event was recorded, or zero if the format has
+	// no per-event timestamps. Parsed leniently by Reader, hence not tagged.
+	Timestamp time.Time `json:"-"`
+
+	// Line is the 1-based line of the event in the transcript, or 0 if the
+	// event wasn't read from a file. Offset is the byte offset of that line.
+	Line   int   `json:"-"`
+	Offset int64 `json:"-"`
+
+	// Session log envelope fields (empty for stream-json)
+	ParentUUID  string `json:"parentUuid,omitempty"`  // UUID of the preceding event in the conversation
+	IsSidechain bool   `json:"isSidechain,omitempty"` // True for subagent (sidechain) messages
+	GitBranch   string `json:"gitBranch,omitempty"`   // Checked-out branch when the event was recorded
 }
 
 // SystemEvent is emitted at the start of a session with configuration info.
 type SystemEvent struct {
 	Event
-	CWD              string   `json:"cwd"`
 	Tools            []string `json:"tools"`
 	MCPServers       []string `json:"mcp_servers"`
 	Model            string   `json:"model"`
@@ -72,6 +111,31 @@ type UserMessage struct {
 	Content []UserContentBlock `json:"content"`
 }
 
+// UnmarshalJSON accepts content as either an array of blocks or a plain
+// string, which session logs use for typed user prompts.
+func (m *UserMessage) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Role = raw.Role
+	m.Content = nil
+	if len(raw.Content) == 0 || string(raw.Content) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw.Content, &text); err == nil {
+		m.Content = []UserContentBlock{{Type: "text", Text: text}}
+		return nil
+	}
+	return json.Unmarshal(raw.Content, &m.Content)
+}
+
 // UserContentBlock represents user content, typically tool results.
 type UserContentBlock struct {
 	Type      string          `json:"type"`                  // "tool_result" or "text"
@@ -95,6 +159,13 @@ type ResultEvent struct {
 	PermissionDenials []PermissionDenial `json:"permission_denials,omitempty"`
 }
 
+// SummaryEvent is a session log entry naming the conversation that ends at LeafUUID.
+type SummaryEvent struct {
+	Event
+	Summary  string `json:"summary"`
+	LeafUUID string `json:"leafUuid"`
+}
+
 // PermissionDenial records when a tool was blocked.
 type PermissionDenial struct {
 	ToolName  string          `json:"tool_name"`
@@ -110,16 +181,29 @@ type ToolCall struct {
 	Result    string          // Tool result content
 	IsError   bool            // Whether the tool returned an error
 	EventUUID string          // UUID of the assistant event containing this call
+	Line      int             // Transcript line of the assistant event (0 if unknown)
+	Offset    int64           // Byte offset of that line
+
+	// ParentToolUseID is the ID of the Task call whose subagent made this
+	// call, or empty if the main agent made it.
+	ParentToolUseID string
+
+	// Wall-clock timing, zero when the transcript format has no timestamps.
+	Timestamp       time.Time     // When the tool_use was emitted
+	ResultTimestamp time.Time     // When the tool_result was recorded
+	Latency         time.Duration // ResultTimestamp - Timestamp
+	Gap             time.Duration // Time since the same agent's previous tool call completed
 }
 
 // Transcript represents a complete parsed session.
 type Transcript struct {
+	Format       Format
 	SessionID    string
 	Model        string
 	CWD          string
 	Tools        []string
-	Events       []any       // All events in order
-	ToolCalls    []ToolCall  // Extracted tool calls for easy iteration
+	Events       []any       // Retained events in order; see Builder
+	ToolCalls    []ToolCall  // Extracted tool calls for easy iteration (all agents, in order)
 	TotalCostUSD float64
 	NumTurns     int
 	IsError      bool